@@ -0,0 +1,67 @@
+package sceneCamera
+
+import (
+	"sort"
+	"sync"
+)
+
+// Bookmark is a named, saved viewpoint.
+type Bookmark struct {
+	Name string
+	Pose
+}
+
+// Bookmarks is a store of named viewpoints.  It is safe to use from several goroutines.  The zero value is an empty store.
+type Bookmarks struct {
+	lock      sync.Mutex
+	bookmarks map[string]Bookmark
+}
+
+// NewBookmarks creates an empty bookmark store.
+func NewBookmarks() *Bookmarks {
+	return &Bookmarks{}
+}
+
+// Save stores the camera's current pose under a name, replacing any bookmark with the same name.
+func (b *Bookmarks) Save(name string, camera *Camera) Bookmark {
+	bookmark := Bookmark{Name: name, Pose: camera.Pose()}
+	b.Set(bookmark)
+	return bookmark
+}
+
+// Set stores a bookmark, replacing any bookmark with the same name.
+func (b *Bookmarks) Set(bookmark Bookmark) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.bookmarks == nil {
+		b.bookmarks = map[string]Bookmark{}
+	}
+	b.bookmarks[bookmark.Name] = bookmark
+}
+
+// Get returns the bookmark with a name.  ok is false if there is no such bookmark.
+func (b *Bookmarks) Get(name string) (bookmark Bookmark, ok bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	bookmark, ok = b.bookmarks[name]
+	return bookmark, ok
+}
+
+// Delete removes the bookmark with a name, if there is one.
+func (b *Bookmarks) Delete(name string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.bookmarks, name)
+}
+
+// Names returns the names of all the bookmarks, in sorted order.
+func (b *Bookmarks) Names() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	names := make([]string, 0, len(b.bookmarks))
+	for name := range b.bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
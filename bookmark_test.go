@@ -0,0 +1,41 @@
+package sceneCamera
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestBookmarks(t *testing.T) {
+	var bookmarks Bookmarks
+	camera := New(Museum)
+	camera.SetPosition(1, 2, 3)
+	saved := bookmarks.Save("entrance", camera)
+	if saved.Name != "entrance" || saved.Pose != camera.Pose() {
+		t.Errorf("expected the camera pose to be saved, got %+v", saved)
+	}
+	bookmarks.Set(Bookmark{Name: "statue", Pose: Pose{Position: mgl32.Vec3{5, 0, 0}, Target: mgl32.Vec3{5, 5, 0}, Up: mgl32.Vec3{0, 0, 1}, FOV: 1}})
+
+	if names := bookmarks.Names(); !reflect.DeepEqual(names, []string{"entrance", "statue"}) {
+		t.Errorf("expected sorted names, got %v", names)
+	}
+	if got, ok := bookmarks.Get("entrance"); !ok || got != saved {
+		t.Errorf("expected %+v, got %+v %v", saved, got, ok)
+	}
+
+	//Saving under an existing name replaces the bookmark
+	camera.SetPosition(9, 9, 9)
+	bookmarks.Save("entrance", camera)
+	if got, _ := bookmarks.Get("entrance"); got.Position != (mgl32.Vec3{9, 9, 9}) {
+		t.Errorf("expected the bookmark to be replaced, got %v", got.Position)
+	}
+
+	bookmarks.Delete("entrance")
+	if _, ok := bookmarks.Get("entrance"); ok {
+		t.Errorf("expected the bookmark to be deleted")
+	}
+	if names := NewBookmarks().Names(); len(names) != 0 {
+		t.Errorf("expected a new store to be empty, got %v", names)
+	}
+}
@@ -0,0 +1,182 @@
+package sceneCamera
+
+import (
+	"sync"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// The matrices and basis vectors are cached, because culling and picking ask for them thousands of times a frame.
+// The camera's fields are exported and can change at any time, so each cache remembers the field values it was computed from, and is recomputed when they no longer match.
+// Comparing a few fields is much cheaper than the normalisations, cross products and inverses it saves.
+//
+// Reading a matrix can update the cache, so the cache has its own lock, and any number of goroutines can read one camera at once.
+// It is held by pointer, so that copying a Camera does not copy the lock.
+
+// viewInputs are the fields that the view matrices and basis vectors are computed from.
+type viewInputs struct {
+	position, target, up mgl32.Vec3
+	orientation          mgl32.Quat
+	ipd, focalLength     float32
+	stereo               StereoModel
+}
+
+// projectionInputs are the fields that the projection matrices are computed from.
+type projectionInputs struct {
+	projection                             ProjectionKind
+	fov, near, far                         float32
+	screenwidth, screenheight, orthoHeight float32
+	ipd, focalLength                       float32
+	stereo                                 StereoModel
+}
+
+// cachedView holds the basis vectors, and the view matrices for each Eye.
+type cachedView struct {
+	valid        bool
+	inputs       viewInputs
+	forward      mgl32.Vec3
+	right        mgl32.Vec3
+	up           mgl32.Vec3
+	views        [3]mgl32.Mat4 //Indexed by Eye
+	inverseViews [3]mgl32.Mat4
+}
+
+// cachedProjection holds an eye's projection matrix and its inverse, computed when it is first asked for.
+type cachedProjection struct {
+	valid      bool
+	projection mgl32.Mat4
+	inverse    mgl32.Mat4
+}
+
+// cachedCombined holds an eye's view-projection matrix, computed when it is first asked for.
+type cachedCombined struct {
+	valid                 bool
+	viewProjection        mgl32.Mat4
+	inverseViewProjection mgl32.Mat4
+}
+
+// matrixCache is the camera's cache of everything computed from its fields.  It is allocated with the Camera, so using it does not allocate.
+type matrixCache struct {
+	sync.Mutex
+	view             cachedView
+	projectionInputs projectionInputs    //The fields that the cached projections were computed from
+	projections      [3]cachedProjection //Indexed by Eye.  Cleared whenever projectionInputs change
+	combined         [3]cachedCombined   //Indexed by Eye.  Cleared whenever the view or projections change
+}
+
+// lockCache locks and returns the camera's cache, for the cache methods below.
+// A Camera that was not made by New, Clone or Restore has no cache, so it gets an empty one for the call, and everything is computed afresh.
+func (c *Camera) lockCache() *matrixCache {
+	cache := c.cache
+	if cache == nil {
+		cache = &matrixCache{}
+	}
+	cache.Lock()
+	return cache
+}
+
+// viewCache returns the cached view, recomputing it if the fields it depends on have changed.  The cache must be locked.
+func (c *Camera) viewCache(cache *matrixCache) *cachedView {
+	inputs := viewInputs{position: c.Position, target: c.Target, up: c.Up, orientation: c.Orientation, ipd: c.IPD, focalLength: c.FocalLength, stereo: c.Stereo}
+	view := &cache.view
+	if view.valid && view.inputs == inputs {
+		return view
+	}
+	view.valid, view.inputs = true, inputs
+	view.forward, view.right, view.up = c.basis()
+	for eye, side := range [3]float32{MonoEye: 0, LeftEye: -1, RightEye: 1} {
+		view.views[eye] = c.eyeViewMatrix(view.forward, view.right, view.up, side)
+		view.inverseViews[eye] = rigidInverse(view.views[eye])
+	}
+	cache.combined = [3]cachedCombined{}
+	return view
+}
+
+// projectionCache returns an eye's cached projection, computing it if it has not been asked for since the fields it depends on changed.
+// Each eye is computed separately, so a mono camera never builds the stereo frustums.  The cache must be locked.
+func (c *Camera) projectionCache(cache *matrixCache, eye Eye) *cachedProjection {
+	inputs := projectionInputs{
+		projection:   c.Projection,
+		fov:          c.FOV,
+		near:         c.Near,
+		far:          c.Far,
+		screenwidth:  c.Screenwidth,
+		screenheight: c.Screenheight,
+		orthoHeight:  c.OrthoHeight,
+		ipd:          c.IPD,
+		focalLength:  c.FocalLength,
+		stereo:       c.Stereo,
+	}
+	if cache.projectionInputs != inputs {
+		cache.projectionInputs = inputs
+		cache.projections = [3]cachedProjection{}
+		cache.combined = [3]cachedCombined{}
+	}
+	projection := &cache.projections[eye]
+	if !projection.valid {
+		switch eye {
+		case LeftEye:
+			c.checkEyeFrustum()
+			projection.projection = c.eyeFrustum(-1)
+		case RightEye:
+			c.checkEyeFrustum()
+			projection.projection = c.eyeFrustum(1)
+		default:
+			projection.projection = c.monoProjection()
+		}
+		projection.inverse = projection.projection.Inv()
+		projection.valid = true
+	}
+	return projection
+}
+
+// combinedCache returns an eye's cached view-projection matrix and its inverse.  The cache must be locked.
+func (c *Camera) combinedCache(cache *matrixCache, eye Eye) *cachedCombined {
+	//Refresh the view and projection first, since they clear the combined cache if they change
+	view := c.viewCache(cache)
+	projection := c.projectionCache(cache, eye)
+	combined := &cache.combined[eye]
+	if !combined.valid {
+		combined.valid = true
+		combined.viewProjection = projection.projection.Mul4(view.views[eye])
+		combined.inverseViewProjection = combined.viewProjection.Inv()
+	}
+	return combined
+}
+
+// rigidInverse inverts a matrix that only rotates and translates, such as a view matrix, more cheaply and accurately than a general inverse.
+func rigidInverse(m mgl32.Mat4) mgl32.Mat4 {
+	rotation := m.Mat3().Transpose()
+	translation := rotation.Mul3x1(m.Col(3).Vec3()).Mul(-1)
+	inverse := rotation.Mat4()
+	inverse.SetCol(3, translation.Vec4(1))
+	return inverse
+}
+
+// InverseViewMatrix returns the inverse of ViewMatrix, which takes camera space to world space.
+func (c *Camera) InverseViewMatrix() mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).inverseViews[MonoEye]
+}
+
+// InverseProjectionMatrix returns the inverse of ProjectionMatrix, which takes clip space to camera space.
+func (c *Camera) InverseProjectionMatrix() mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.projectionCache(cache, MonoEye).inverse
+}
+
+// ViewProjectionMatrix returns ProjectionMatrix times ViewMatrix, which takes world space to clip space.
+func (c *Camera) ViewProjectionMatrix() mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.combinedCache(cache, MonoEye).viewProjection
+}
+
+// InverseViewProjectionMatrix returns the inverse of ViewProjectionMatrix, which takes clip space back to world space.
+func (c *Camera) InverseViewProjectionMatrix() mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.combinedCache(cache, MonoEye).inverseViewProjection
+}
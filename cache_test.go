@@ -0,0 +1,204 @@
+package sceneCamera
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// uncached returns a copy of the camera with an empty cache, so everything it returns is computed afresh.
+func uncached(c *Camera) *Camera {
+	fresh := *c
+	fresh.cache = nil
+	return &fresh
+}
+
+func assertMatchesUncached(t *testing.T, camera *Camera) {
+	t.Helper()
+	fresh := uncached(camera)
+	assertVec3(t, camera.ForwardsVector(), fresh.ForwardsVector())
+	assertVec3(t, camera.RightWardsVector(), fresh.RightWardsVector())
+	assertVec3(t, camera.UpwardsVector(), fresh.UpwardsVector())
+	for _, eye := range []Eye{MonoEye, LeftEye, RightEye} {
+		assertMat4(t, camera.EyeViewMatrix(eye), fresh.EyeViewMatrix(eye))
+		assertMat4(t, camera.EyeViewProjectionMatrix(eye), fresh.EyeViewProjectionMatrix(eye))
+	}
+	assertMat4(t, camera.ProjectionMatrix(), fresh.ProjectionMatrix())
+	assertMat4(t, camera.LeftEyeFrustum(), fresh.LeftEyeFrustum())
+	assertMat4(t, camera.RightEyeFrustum(), fresh.RightEyeFrustum())
+}
+
+func TestCacheFollowsFields(t *testing.T) {
+	testCases := []struct {
+		name   string
+		change func(c *Camera)
+	}{
+		{"position", func(c *Camera) { c.Position = mgl32.Vec3{1, 2, 3} }},
+		{"target", func(c *Camera) { c.Target = mgl32.Vec3{3, 0, 0} }},
+		{"up", func(c *Camera) { c.Up = mgl32.Vec3{1, 0, 0} }},
+		{"orientation", func(c *Camera) { c.Orientation = mgl32.QuatRotate(0.3, mgl32.Vec3{0, 1, 0}) }},
+		{"ipd", func(c *Camera) { c.IPD = 0.5 }},
+		{"fov", func(c *Camera) { c.FOV = 1 }},
+		{"near and far", func(c *Camera) { c.Near, c.Far = 0.5, 50 }},
+		{"screen", func(c *Camera) { c.Screenwidth, c.Screenheight = 800, 600 }},
+		{"projection", func(c *Camera) { c.Projection = Orthographic }},
+		{"ortho height", func(c *Camera) { c.Projection, c.OrthoHeight = Orthographic, 3 }},
+		{"move", func(c *Camera) { c.Move(YawLeft, 0.4) }},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			camera := New(FPS)
+			assertMatchesUncached(t, camera)
+			testCase.change(camera)
+			assertMatchesUncached(t, camera)
+		})
+	}
+}
+
+func TestCachedInverses(t *testing.T) {
+	camera := New(Museum)
+	camera.Move(Left, 0.7)
+	assertMat4(t, camera.InverseViewMatrix().Mul4(camera.ViewMatrix()), mgl32.Ident4())
+	assertMat4(t, camera.InverseProjectionMatrix().Mul4(camera.ProjectionMatrix()), mgl32.Ident4())
+	assertMat4(t, camera.ViewProjectionMatrix(), camera.ProjectionMatrix().Mul4(camera.ViewMatrix()))
+	//The general inverse loses a little more precision
+	identity := camera.InverseViewProjectionMatrix().Mul4(camera.ViewProjectionMatrix())
+	for i, value := range identity {
+		if abs(value-mgl32.Ident4()[i]) > 1e-4 {
+			t.Errorf("expected the identity, got %v", identity)
+			break
+		}
+	}
+
+	//The inverse view takes the camera-space origin to the camera position
+	assertVec3(t, mgl32.TransformCoordinate(mgl32.Vec3{}, camera.InverseViewMatrix()), camera.Position)
+}
+
+func TestCachedMatricesDoNotAllocate(t *testing.T) {
+	camera := New(FPS)
+	allocations := testing.AllocsPerRun(100, func() {
+		camera.ViewMatrix()
+		camera.ForwardsVector()
+		camera.RightWardsVector()
+		camera.UpwardsVector()
+		camera.ViewProjectionMatrix()
+		camera.InverseViewProjectionMatrix()
+		camera.LeftEyeFrustum()
+		camera.RightEyeViewMatrix()
+		camera.Move(YawLeft, 0.001)
+	})
+	if allocations != 0 {
+		t.Errorf("expected no allocations, got %v", allocations)
+	}
+}
+
+func TestEyeProjectionsAreLazy(t *testing.T) {
+	camera := New(FPS)
+	camera.LeftEyeFrustum()
+	//Zooming a mono camera only rebuilds the mono projection
+	camera.FOV = 1.2
+	camera.ProjectionMatrix()
+	camera.InverseViewProjectionMatrix()
+	if camera.cache.projections[LeftEye].valid || camera.cache.projections[RightEye].valid {
+		t.Error("expected the eye frustums to wait until they are asked for")
+	}
+	assertMatchesUncached(t, camera)
+}
+
+func TestConcurrentReaders(t *testing.T) {
+	//Readers fill the cache, so run with -race to check that they do not collide
+	camera := New(Museum)
+	camera.Move(Left, 0.7)
+	camera.FOV = 1.2
+	expected := uncached(camera)
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for j := 0; j < 100; j++ {
+				assertMat4(t, camera.ViewMatrix(), expected.ViewMatrix())
+				assertMat4(t, camera.EyeViewProjectionMatrix(LeftEye), expected.EyeViewProjectionMatrix(LeftEye))
+				assertMat4(t, camera.InverseViewProjectionMatrix(), expected.InverseViewProjectionMatrix())
+				assertVec3(t, camera.ForwardsVector(), expected.ForwardsVector())
+			}
+		}()
+	}
+	wait.Wait()
+}
+
+func TestEyeViewProjectionPanicsLikeEyeFrustum(t *testing.T) {
+	camera := New(FPS)
+	camera.ViewProjectionMatrix()
+	camera.FocalLength = 0
+	//The mono matrices do not use FocalLength, but the eyes do
+	assertFiniteMat4(t, camera.ViewProjectionMatrix())
+	for _, eye := range []Eye{LeftEye, RightEye} {
+		assertPanics(t, func() { camera.EyeViewProjectionMatrix(eye) })
+	}
+}
+
+func BenchmarkViewMatrix(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.ViewMatrix()
+	}
+}
+
+func BenchmarkBasisVectors(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.ForwardsVector()
+		camera.RightWardsVector()
+		camera.UpwardsVector()
+	}
+}
+
+func BenchmarkViewProjectionMatrix(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.ViewProjectionMatrix()
+	}
+}
+
+func BenchmarkStereoMatrices(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.LeftEyeViewMatrix()
+		camera.LeftEyeFrustum()
+		camera.RightEyeViewMatrix()
+		camera.RightEyeFrustum()
+	}
+}
+
+func BenchmarkFrustumCulling(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.ViewFrustum().ContainsPoint(mgl32.Vec3{1, 2, 3})
+	}
+}
+
+// BenchmarkFPSMove recomputes the cache on every move, since each move changes the camera.
+func BenchmarkFPSMove(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.Move(YawLeft, 0.001)
+	}
+}
+
+// BenchmarkViewMatrixUncached is the cost of a cache miss, for comparison.
+func BenchmarkViewMatrixUncached(b *testing.B) {
+	camera := New(FPS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		camera.cache.view.valid = false
+		camera.ViewMatrix()
+	}
+}
@@ -0,0 +1,218 @@
+package sceneCamera
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Camera64 is a Camera for worlds too large for float32 positions, which jitter visibly far from the origin.
+//
+// The embedded Camera works in local coordinates, relative to Origin, which is held in float64.  Move, Update and the other methods that move the camera keep it near its local origin by moving Origin along the ground plane whenever the camera strays more than RebaseDistance from it, so every mode works as usual with small coordinates.
+// Camera64 shadows each of the embedded Camera's movement methods to do this.  Setting the embedded Camera's fields directly skips it, so call Rebase afterwards.
+// Because Origin stays on the ground plane, GroundPlaneNormal describes the same ground in local and world coordinates.
+//
+// For rendering, RelativeViewMatrix and RelativeTo put the camera at the origin, so that the GPU only sees coordinates close to the camera.
+// Other positions given to the embedded Camera, such as a Follow subject, are in local coordinates; convert them with Local.
+type Camera64 struct {
+	*Camera
+	Origin         mgl64.Vec3 //The world position of the embedded Camera's origin.  Always on the ground plane
+	RebaseDistance float64    //How far the camera may move from Origin before the movement methods rebase it.  Zero to rebase on every call
+}
+
+// New64 creates a float64 camera in the selected movement mode, at the same place as New.
+func New64(mode Mode) *Camera64 {
+	return &Camera64{Camera: New(mode), RebaseDistance: 1024}
+}
+
+func vec64(v mgl32.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{float64(v[0]), float64(v[1]), float64(v[2])}
+}
+
+func vec32(v mgl64.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{float32(v[0]), float32(v[1]), float32(v[2])}
+}
+
+// Local converts a world position to the embedded Camera's local coordinates.
+func (c *Camera64) Local(world mgl64.Vec3) mgl32.Vec3 {
+	return vec32(world.Sub(c.Origin))
+}
+
+// World converts a position in the embedded Camera's local coordinates to world coordinates.
+func (c *Camera64) World(local mgl32.Vec3) mgl64.Vec3 {
+	return c.Origin.Add(vec64(local))
+}
+
+// WorldPosition64 returns the position of the camera in world space.
+func (c *Camera64) WorldPosition64() mgl64.Vec3 {
+	return c.World(c.Position)
+}
+
+// WorldTarget64 returns the position of the target in world space.
+func (c *Camera64) WorldTarget64() mgl64.Vec3 {
+	return c.World(c.Target)
+}
+
+// SetWorldPosition64 teleports the camera to a position in world space.  Like SetPosition, it leaves the target where it is.
+func (c *Camera64) SetWorldPosition64(position mgl64.Vec3) {
+	target := c.WorldTarget64()
+	c.setOrigin(c.groundUnder(position))
+	c.Position = c.Local(position)
+	c.Target = c.Local(target)
+}
+
+// LookAt64 points the camera at a position in world space.
+// If the camera has strayed from Origin it is rebased first, so that the view direction is worked out from small coordinates.
+func (c *Camera64) LookAt64(target mgl64.Vec3) {
+	c.rebaseIfFar()
+	local := c.Local(target)
+	c.Camera.LookAt(local.X(), local.Y(), local.Z())
+}
+
+// LookAt points the camera at a position in local coordinates, as Camera.LookAt does, rebasing first like LookAt64.
+func (c *Camera64) LookAt(x, y, z float32) {
+	c.LookAt64(c.World(mgl32.Vec3{x, y, z}))
+}
+
+// Move moves the camera as Camera.Move does, then rebases it if it has strayed from Origin.
+func (c *Camera64) Move(direction Direction, amount float32) {
+	c.Camera.Move(direction, amount)
+	c.rebaseIfFar()
+}
+
+// Update moves the camera as Camera.Update does, then rebases it if it has strayed from Origin.
+func (c *Camera64) Update(dt float32) {
+	c.Camera.Update(dt)
+	c.rebaseIfFar()
+}
+
+// SetPosition moves the camera as Camera.SetPosition does, then rebases it if it has strayed from Origin.
+func (c *Camera64) SetPosition(x, y, z float32) {
+	c.Camera.SetPosition(x, y, z)
+	c.rebaseIfFar()
+}
+
+// Translate moves the camera as Camera.Translate does, then rebases it if it has strayed from Origin.
+func (c *Camera64) Translate(x, y, z float32) {
+	c.Camera.Translate(x, y, z)
+	c.rebaseIfFar()
+}
+
+// SetPose moves the camera as Camera.SetPose does, then rebases it if it has strayed from Origin.
+func (c *Camera64) SetPose(pose Pose) {
+	c.Camera.SetPose(pose)
+	c.rebaseIfFar()
+}
+
+// FlyTo starts a flight as Camera.FlyTo does.  A flight with no duration jumps straight to the bookmark, so the camera is rebased if it has strayed from Origin.
+func (c *Camera64) FlyTo(bookmark Bookmark, duration float32, easing Easing) {
+	c.Camera.FlyTo(bookmark, duration, easing)
+	c.rebaseIfFar()
+}
+
+// Restore restores the camera as Camera.Restore does, then rebases it if it has strayed from Origin.  The state is in local coordinates.
+func (c *Camera64) Restore(state CameraState) {
+	c.Camera.Restore(state)
+	c.rebaseIfFar()
+}
+
+// ZoomToCursor zooms the camera as Camera.ZoomToCursor does, then rebases it if it has strayed from Origin.
+func (c *Camera64) ZoomToCursor(x, y, amount float32) bool {
+	ok := c.Camera.ZoomToCursor(x, y, amount)
+	c.rebaseIfFar()
+	return ok
+}
+
+// DragGround pans the camera as Camera.DragGround does, then rebases it if it has strayed from Origin.
+func (c *Camera64) DragGround(x, y float32) bool {
+	ok := c.Camera.DragGround(x, y)
+	c.rebaseIfFar()
+	return ok
+}
+
+// Arcball orbits the camera as Camera.Arcball does, then rebases it if it has strayed from Origin.
+func (c *Camera64) Arcball(fromX, fromY, toX, toY float32) {
+	c.Camera.Arcball(fromX, fromY, toX, toY)
+	c.rebaseIfFar()
+}
+
+// Rebase moves Origin to the point on the ground plane under the camera, and shifts the embedded Camera's local coordinates to match.
+// Nothing moves in world space.  The movement methods call it when needed; call it after setting the embedded Camera's fields directly.
+func (c *Camera64) Rebase() {
+	c.setOrigin(c.groundUnder(c.WorldPosition64()))
+}
+
+func (c *Camera64) rebaseIfFar() {
+	if float64(c.Position.Sub(c.groundNormal().Mul(c.Position.Dot(c.groundNormal()))).Len()) > c.RebaseDistance {
+		c.Rebase()
+	}
+}
+
+// groundNormal returns GroundPlaneNormal as a unit vector, or +Z if it is zero.
+func (c *Camera64) groundNormal() mgl32.Vec3 {
+	if c.GroundPlaneNormal.Len() == 0 {
+		return mgl32.Vec3{0, 0, 1}
+	}
+	return c.GroundPlaneNormal.Normalize()
+}
+
+// groundUnder returns the point on the ground plane under a world position.
+func (c *Camera64) groundUnder(world mgl64.Vec3) mgl64.Vec3 {
+	normal := vec64(c.groundNormal())
+	return world.Sub(normal.Mul(world.Dot(normal)))
+}
+
+// setOrigin moves Origin, and shifts everything the embedded Camera holds in local coordinates so that nothing moves in world space.
+func (c *Camera64) setOrigin(origin mgl64.Vec3) {
+	offset := vec32(origin.Sub(c.Origin))
+	c.Origin = origin
+	c.Camera.shiftOrigin(offset)
+}
+
+// shiftOrigin moves the camera's local origin by offset, keeping everything where it is in world space.
+func (c *Camera) shiftOrigin(offset mgl32.Vec3) {
+	c.Position = c.Position.Sub(offset)
+	c.Target = c.Target.Sub(offset)
+	c.dragAnchor = c.dragAnchor.Sub(offset)
+	if c.transition.active {
+		c.transition.path.translate(offset.Mul(-1))
+		c.transition.end.Position = c.transition.end.Position.Sub(offset)
+		c.transition.end.Target = c.transition.end.Target.Sub(offset)
+	}
+	if follow, ok := c.activeController().(*followController); ok {
+		follow.subject.Position = follow.subject.Position.Sub(offset)
+		follow.lookAt = follow.lookAt.Sub(offset)
+	}
+}
+
+// ViewMatrix64 returns the full view matrix, in float64, for world space positions.
+func (c *Camera64) ViewMatrix64() mgl64.Mat4 {
+	var rotation mgl64.Mat4
+	for i, value := range c.Orientation.Mat4() {
+		rotation[i] = float64(value)
+	}
+	position := c.WorldPosition64()
+	return rotation.Mul4(mgl64.Translate3D(-position.X(), -position.Y(), -position.Z()))
+}
+
+// RelativeViewMatrix returns the view matrix with the translation removed, for camera-relative rendering.
+// It transforms positions relative to the camera, from RelativeTo or RelativeModelMatrix, into camera space.
+func (c *Camera64) RelativeViewMatrix() mgl32.Mat4 {
+	return c.Orientation.Mat4()
+}
+
+// RelativeTo returns a world position relative to the camera, small enough for float32 near the camera.  This is how to rebase object positions for RelativeViewMatrix.
+func (c *Camera64) RelativeTo(world mgl64.Vec3) mgl32.Vec3 {
+	return vec32(world.Sub(c.WorldPosition64()))
+}
+
+// RelativeModelMatrix converts an object's float64 model matrix into a float32 one that places it relative to the camera, for use with RelativeViewMatrix.
+// The subtraction is done in float64, so distant objects keep their precision until it is no longer visible.
+func (c *Camera64) RelativeModelMatrix(model mgl64.Mat4) mgl32.Mat4 {
+	position := c.WorldPosition64()
+	relative := mgl64.Translate3D(-position.X(), -position.Y(), -position.Z()).Mul4(model)
+	var result mgl32.Mat4
+	for i, value := range relative {
+		result[i] = float32(value)
+	}
+	return result
+}
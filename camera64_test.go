@@ -0,0 +1,151 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestCamera64SmallStepsFarAway(t *testing.T) {
+	//An FPS camera 300 km from the origin, 2 units above the ground, looking along +X
+	camera := New64(FPS)
+	camera.SetUp(0, 0, 1)
+	camera.SetWorldPosition64(mgl64.Vec3{300000, -200000, 2})
+	camera.LookAt64(mgl64.Vec3{300010, -200000, 2})
+	//A float32 at 300 km has a resolution of over 3 cm, so these steps would be lost
+	for i := 0; i < 1000; i++ {
+		camera.Move(Forward, 0.001)
+	}
+	assertVec3Near(t, camera.Position, camera.Local(mgl64.Vec3{300001, -200000, 2}), 1e-4)
+	if camera.Position.Len() > 10 {
+		t.Errorf("expected local coordinates near the origin, got %v", camera.Position)
+	}
+}
+
+func TestCamera64RebaseKeepsWorld(t *testing.T) {
+	//An FPS camera 300 km from the origin, 2 units above the ground, looking along +X
+	camera := New64(FPS)
+	camera.SetUp(0, 0, 1)
+	camera.SetWorldPosition64(mgl64.Vec3{300000, -200000, 2})
+	camera.LookAt64(mgl64.Vec3{300010, -200000, 2})
+	camera.RebaseDistance = 100
+	position, target := camera.WorldPosition64(), camera.WorldTarget64()
+	ground, ok := camera.GroundPointAt(100, 900)
+	if !ok {
+		t.Fatalf("expected ground under the pixel")
+	}
+	worldGround := camera.World(ground)
+
+	for i := 0; i < 50; i++ {
+		camera.Move(Forward, 10)
+	}
+	offset := mgl64.Vec3{500, 0, 0}
+	assertVec3Near(t, camera.Position, camera.Local(position.Add(offset)), 1e-2)
+	assertVec3Near(t, camera.Target, camera.Local(target.Add(offset)), 1e-2)
+	if camera.Origin.X() < 300400 {
+		t.Errorf("expected the origin to follow the camera, got %v", camera.Origin)
+	}
+	if camera.Origin.Z() != 0 {
+		t.Errorf("expected the origin to stay on the ground, got %v", camera.Origin)
+	}
+
+	//The ground is the same plane after rebasing
+	ground, _ = camera.GroundPointAt(100, 900)
+	assertVec3Near(t, ground, camera.Local(worldGround.Add(offset)), 1e-2)
+}
+
+func TestCamera64RebaseDuringFlyTo(t *testing.T) {
+	//An FPS camera 300 km from the origin, 2 units above the ground, looking along +X
+	camera := New64(FPS)
+	camera.SetUp(0, 0, 1)
+	camera.SetWorldPosition64(mgl64.Vec3{300000, -200000, 2})
+	camera.LookAt64(mgl64.Vec3{300010, -200000, 2})
+	destination := camera.Pose()
+	destination.Position = destination.Position.Add(mgl32.Vec3{0, 50, 0})
+	destination.Target = destination.Target.Add(mgl32.Vec3{0, 50, 0})
+	expected := camera.World(destination.Position)
+
+	camera.FlyTo(Bookmark{Pose: destination}, 1, EaseLinear)
+	camera.Update(0.5)
+	camera.Rebase()
+	camera.Update(0.6)
+	assertVec3Near(t, camera.Position, camera.Local(expected), 1e-3)
+}
+
+func TestCamera64RelativeRendering(t *testing.T) {
+	//An FPS camera 300 km from the origin, 2 units above the ground, looking along +X
+	camera := New64(FPS)
+	camera.SetUp(0, 0, 1)
+	camera.SetWorldPosition64(mgl64.Vec3{300000, -200000, 2})
+	camera.LookAt64(mgl64.Vec3{300010, -200000, 2})
+	camera.Move(YawLeft, 0.3)
+	camera.Move(PitchUp, 0.2)
+	point := mgl64.Vec3{300020, -199990, 7}
+
+	expected := camera.ViewMatrix64().Mul4x1(point.Vec4(1))
+	relative := camera.RelativeViewMatrix().Mul4x1(camera.RelativeTo(point).Vec4(1))
+	for i := 0; i < 4; i++ {
+		if math.Abs(float64(relative[i])-expected[i]) > 1e-4 {
+			t.Fatalf("expected camera space %v, got %v", expected, relative)
+		}
+	}
+
+	//An object's model matrix, placed relative to the camera
+	model := mgl64.Translate3D(point.X(), point.Y(), point.Z()).Mul4(mgl64.Scale3D(2, 2, 2))
+	corner := camera.RelativeModelMatrix(model).Mul4x1(mgl32.Vec4{1, 0, 0, 1})
+	assertVec3(t, corner.Vec3(), camera.RelativeTo(point.Add(mgl64.Vec3{2, 0, 0})))
+}
+
+func TestCamera64LocalAndWorld(t *testing.T) {
+	//An FPS camera 300 km from the origin, 2 units above the ground, looking along +X
+	camera := New64(FPS)
+	camera.SetUp(0, 0, 1)
+	camera.SetWorldPosition64(mgl64.Vec3{300000, -200000, 2})
+	camera.LookAt64(mgl64.Vec3{300010, -200000, 2})
+	world := mgl64.Vec3{300003.5, -200001.25, 4}
+	if roundTrip := camera.World(camera.Local(world)); roundTrip.Sub(world).Len() > 1e-6 {
+		t.Errorf("expected %v back, got %v", world, roundTrip)
+	}
+	assertVec3(t, camera.Local(camera.WorldPosition64()), camera.Position)
+}
+
+func TestCamera64MovementMethodsRebase(t *testing.T) {
+	far := Pose{Position: mgl32.Vec3{1e7, 0, 2}, Target: mgl32.Vec3{1e7, 1, 2}, Up: mgl32.Vec3{0, 0, 1}, FOV: 1}
+	for name, move := range map[string]func(c *Camera64){
+		"SetPosition": func(c *Camera64) { c.SetPosition(1e7, 0, 2) },
+		"Translate":   func(c *Camera64) { c.Translate(1e7, 0, -3) },
+		"SetPose":     func(c *Camera64) { c.SetPose(far) },
+		"FlyTo":       func(c *Camera64) { c.FlyTo(Bookmark{Pose: far}, 0, nil) },
+		"Restore": func(c *Camera64) {
+			state := c.Snapshot()
+			state.Position, state.Target = far.Position, far.Target
+			c.Restore(state)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			camera := New64(FPS)
+			camera.SetUp(0, 0, 1)
+			move(camera)
+			if camera.Position.Len() > 10 {
+				t.Errorf("expected local coordinates near the origin, got %v", camera.Position)
+			}
+			if position := camera.WorldPosition64(); position.Sub(mgl64.Vec3{1e7, 0, 2}).Len() > 1e-6 {
+				t.Errorf("expected the camera at 1e7, got %v", position)
+			}
+		})
+	}
+}
+
+func TestCamera64LookAtFarAway(t *testing.T) {
+	camera := New64(FPS)
+	camera.SetUp(0, 0, 1)
+	//Setting the field skips the rebase, so LookAt64 must catch up before working out the view
+	camera.Position = mgl32.Vec3{1e7, 0, 2}
+	camera.LookAt64(mgl64.Vec3{1e7 + 0.25, 1, 2})
+	assertVec3(t, camera.ForwardsVector(), mgl32.Vec3{0.25, 1, 0}.Normalize())
+	if position := camera.WorldPosition64(); position.Sub(mgl64.Vec3{1e7, 0, 2}).Len() > 1e-6 {
+		t.Errorf("expected the camera to stay at 1e7, got %v", position)
+	}
+}
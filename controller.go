@@ -0,0 +1,104 @@
+package sceneCamera
+
+import "sync"
+
+// Controller implements a camera mode: how the camera responds to movement commands, and how it changes over time.
+//
+// Each camera creates its own controller when it enters a mode, so a controller can keep per-camera state.
+// The built-in modes are controllers too, and custom modes are added with RegisterMode.
+type Controller interface {
+	// Enter is called when SetMode or BlendToMode switches the camera to this controller's mode, before any other method.
+	// It is not called for a camera created in the mode, restored into it, or given it by setting Mode directly, since those cameras are already set up as they should be.
+	Enter(camera *Camera)
+	// Exit is called when SetMode or BlendToMode switches the camera to another mode.  The controller is not used again.
+	Exit(camera *Camera)
+	// Move handles a movement command from Move, MoveChecked or Update.
+	Move(camera *Camera, direction Direction, amount float32)
+	// Supports reports whether Move responds to a direction.
+	Supports(direction Direction) bool
+	// Update is called by Camera.Update, after any movement from desired velocities, with the elapsed time in seconds.
+	Update(camera *Camera, dt float32)
+}
+
+// modeEntry is a registered camera mode.
+type modeEntry struct {
+	name          string
+	newController func() Controller
+	supported     [len(directionNames)]bool //Which directions the mode's controller supports, recorded when the mode is registered
+}
+
+// newModeEntry registers a controller constructor, asking one controller which directions it supports.
+func newModeEntry(name string, newController func() Controller) modeEntry {
+	entry := modeEntry{name: name, newController: newController}
+	controller := newController()
+	for direction := range entry.supported {
+		entry.supported[direction] = controller.Supports(Direction(direction))
+	}
+	return entry
+}
+
+// firstCustomMode is the first Mode returned by RegisterMode, leaving room below for more built-in modes.
+const firstCustomMode Mode = 100
+
+var (
+	modesLock sync.RWMutex
+	modes     = map[Mode]modeEntry{
+		Museum: newModeEntry("Museum", func() Controller { return museumController{} }),
+		FPS:    newModeEntry("FPS", func() Controller { return fpsController{} }),
+		RTS:    newModeEntry("RTS", func() Controller { return rtsController{} }),
+		Flight: newModeEntry("Flight", func() Controller { return flightController{} }),
+		Follow: newModeEntry("Follow", func() Controller { return &followController{} }),
+	}
+	nextMode = firstCustomMode
+)
+
+// RegisterMode adds a custom camera mode, and returns the Mode to pass to New or SetMode.
+// newController is called each time a camera enters the mode, and once by RegisterMode to record which directions the mode supports, so Supports must not depend on the controller's state.
+// The name is returned by the Mode's String method.
+//
+// Mode numbers are assigned in the order modes are registered, so register them in the same order at start up if their numbers are saved with the camera state.
+// RegisterMode panics if newController is nil.
+func RegisterMode(name string, newController func() Controller) Mode {
+	if newController == nil {
+		panic("sceneCamera: RegisterMode with a nil controller constructor")
+	}
+	entry := newModeEntry(name, newController)
+	modesLock.Lock()
+	defer modesLock.Unlock()
+	mode := nextMode
+	nextMode++
+	modes[mode] = entry
+	return mode
+}
+
+// lookupMode returns the registration for a mode.
+func lookupMode(mode Mode) (modeEntry, bool) {
+	modesLock.RLock()
+	defer modesLock.RUnlock()
+	entry, ok := modes[mode]
+	return entry, ok
+}
+
+// Controller returns the controller for the camera's current mode, or nil if the mode is not registered.
+// Custom modes can use it to reach their own controller's settings.
+func (c *Camera) Controller() Controller {
+	return c.activeController()
+}
+
+// activeController returns the controller for the current mode, creating one if Mode has changed since the last call.
+// Mode is an exported field, so it may have been set directly instead of through SetMode, and a Camera copied by value still holds the original's controller.
+// Either way the new controller is created without calling Exit or Enter: only SetMode converts the view for the new mode.
+func (c *Camera) activeController() Controller {
+	if c.controller != nil && c.controllerMode == c.Mode && c.controllerCamera == c {
+		return c.controller
+	}
+	c.controller = nil
+	entry, ok := lookupMode(c.Mode)
+	if !ok {
+		return nil
+	}
+	c.controller = entry.newController()
+	c.controllerMode = c.Mode
+	c.controllerCamera = c
+	return c.controller
+}
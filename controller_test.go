@@ -0,0 +1,198 @@
+package sceneCamera
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// turretController is a custom mode for the tests: it only yaws, and records what the camera asks of it.
+type turretController struct {
+	calls   []string
+	elapsed float32
+}
+
+func (t *turretController) Enter(c *Camera) { t.calls = append(t.calls, "enter") }
+func (t *turretController) Exit(c *Camera)  { t.calls = append(t.calls, "exit") }
+
+func (t *turretController) Supports(direction Direction) bool {
+	return direction == YawLeft || direction == YawRight
+}
+
+func (t *turretController) Move(c *Camera, direction Direction, amount float32) {
+	t.calls = append(t.calls, direction.String())
+	switch direction {
+	case YawLeft:
+	case YawRight:
+		amount = -amount
+	default:
+		return
+	}
+	toTarget := mgl32.QuatRotate(amount, c.Up).Rotate(c.TargetVector())
+	c.LookAt(c.Position.Add(toTarget).Elem())
+}
+
+func (t *turretController) Update(c *Camera, dt float32) {
+	t.elapsed += dt
+}
+
+var turretMode = RegisterMode("Turret", func() Controller { return &turretController{} })
+
+func TestRegisterMode(t *testing.T) {
+	if turretMode < firstCustomMode {
+		t.Errorf("expected a custom mode number, got %d", int(turretMode))
+	}
+	if turretMode.String() != "Turret" {
+		t.Errorf("expected the registered name, got %q", turretMode.String())
+	}
+	if !turretMode.Supports(YawLeft) || turretMode.Supports(Forward) {
+		t.Errorf("expected Supports to ask the controller")
+	}
+	if turretMode.Supports(Direction(-1)) || turretMode.Supports(Direction(99)) {
+		t.Errorf("expected unknown directions to be unsupported")
+	}
+	other := RegisterMode("Drone", func() Controller { return museumController{} })
+	if other == turretMode {
+		t.Errorf("expected each registration to get its own mode")
+	}
+	assertPanics(t, func() { RegisterMode("Broken", nil) })
+}
+
+func TestSupportsDoesNotCreateControllers(t *testing.T) {
+	created := 0
+	mode := RegisterMode("Counted", func() Controller {
+		created++
+		return &turretController{}
+	})
+	created = 0
+	if allocs := testing.AllocsPerRun(100, func() { mode.Supports(YawLeft) }); allocs != 0 {
+		t.Errorf("expected Supports not to allocate, got %v allocations", allocs)
+	}
+	if created != 0 {
+		t.Errorf("expected Supports not to create controllers, got %d", created)
+	}
+}
+
+func TestCustomController(t *testing.T) {
+	camera := New(turretMode)
+	turret, ok := camera.Controller().(*turretController)
+	if !ok {
+		t.Fatalf("expected the turret controller, got %T", camera.Controller())
+	}
+
+	position := camera.Position
+	camera.Move(YawLeft, 0.5)
+	camera.Move(Forward, 1)
+	if camera.Position != position {
+		t.Errorf("expected the turret to stay put, moved to %v", camera.Position)
+	}
+	if err := camera.MoveChecked(Forward, 1); !errors.Is(err, ErrUnsupportedDirection) {
+		t.Errorf("expected ErrUnsupportedDirection, got %v", err)
+	}
+	if err := camera.MoveChecked(YawRight, 0.5); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	assertVec3Near(t, camera.ForwardsVector(), mgl32.Vec3{0, 0, -1}, 1e-4)
+
+	//Update drives the controller through Move, then calls its Update
+	camera.Acceleration, camera.TurnAcceleration = 0, 0
+	camera.SetDesiredVelocity(YawLeft, 1)
+	camera.Update(0.25)
+	if turret.elapsed != 0.25 {
+		t.Errorf("expected Update to reach the controller, got %v", turret.elapsed)
+	}
+
+	camera.SetMode(FPS)
+	//A camera created in the mode is not entered, but switching away exits
+	expected := []string{"YawLeft", "Forward", "YawRight", "YawLeft", "exit"}
+	if !reflect.DeepEqual(turret.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, turret.calls)
+	}
+	if _, ok := camera.Controller().(fpsController); !ok {
+		t.Errorf("expected the FPS controller, got %T", camera.Controller())
+	}
+}
+
+func TestControllerFollowsModeField(t *testing.T) {
+	camera := New(FPS)
+	//Setting the field directly switches controller on the next use, without entering it
+	camera.Mode = turretMode
+	camera.Move(YawLeft, 0.1)
+	turret, ok := camera.Controller().(*turretController)
+	if !ok {
+		t.Fatalf("expected the turret controller, got %T", camera.Controller())
+	}
+	if !reflect.DeepEqual(turret.calls, []string{"YawLeft"}) {
+		t.Errorf("expected the controller to move without being entered, got %v", turret.calls)
+	}
+
+	//Each camera has its own controller
+	if New(turretMode).Controller() == camera.Controller() {
+		t.Errorf("expected a new controller for each camera")
+	}
+
+	camera.Mode = Mode(0)
+	if camera.Controller() != nil {
+		t.Errorf("expected no controller for an unknown mode")
+	}
+	if !reflect.DeepEqual(turret.calls, []string{"YawLeft"}) {
+		t.Errorf("expected the controller not to be exited, got %v", turret.calls)
+	}
+	camera.Move(Forward, 1)
+	camera.Update(1)
+}
+
+func TestSetModeEntersController(t *testing.T) {
+	camera := New(FPS)
+	camera.SetMode(turretMode)
+	turret := camera.Controller().(*turretController)
+	camera.SetMode(turretMode)
+	camera.Move(YawLeft, 0.1)
+	camera.SetMode(FPS)
+	if !reflect.DeepEqual(turret.calls, []string{"enter", "YawLeft", "exit"}) {
+		t.Errorf("expected one enter and one exit, got %v", turret.calls)
+	}
+}
+
+func TestUnenteredMuseumKeepsTarget(t *testing.T) {
+	//Museum's Enter moves the pivot to the ground, which must not happen to a camera that is already set up
+	for name, camera := range map[string]*Camera{
+		"literal": {Position: mgl32.Vec3{0, 0, 5}, Target: mgl32.Vec3{0, 2, 0}, Up: mgl32.Vec3{0, 1, 0}, GroundPlaneNormal: mgl32.Vec3{0, 1, 0}, Mode: Museum},
+		"field":   New(FPS),
+	} {
+		t.Run(name, func(t *testing.T) {
+			camera.Target = mgl32.Vec3{0, 2, 0}
+			camera.Mode = Museum
+			camera.Move(Left, 0.1)
+			assertVec3(t, camera.Target, mgl32.Vec3{0, 2, 0})
+		})
+	}
+}
+
+func TestCopiedCameraHasItsOwnController(t *testing.T) {
+	for name, copyCamera := range map[string]func(*Camera) *Camera{
+		"Clone": (*Camera).Clone,
+		"value": func(c *Camera) *Camera { copied := *c; return &copied },
+	} {
+		t.Run(name, func(t *testing.T) {
+			camera := New(Follow)
+			camera.SetFollowSubject(testSubject)
+			camera.Update(0.01)
+			assertVec3(t, camera.Position, mgl32.Vec3{4, 0, 2})
+
+			//Following a different subject with the copy must not move the original
+			copied := copyCamera(camera)
+			copied.SetFollowSubject(Subject{Position: mgl32.Vec3{100, 0, 0}, Forward: mgl32.Vec3{1, 0, 0}})
+			camera.Update(0.1)
+			assertVec3(t, camera.Position, mgl32.Vec3{4, 0, 2})
+			if copied.Controller() == camera.Controller() {
+				t.Errorf("expected the copy to have its own controller")
+			}
+
+			copied.Update(0.01)
+			assertVec3(t, copied.Position, mgl32.Vec3{94, 0, 2})
+		})
+	}
+}
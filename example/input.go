@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 "sync"
+	Cameras "github.com/donomii/sceneCamera"
 	"github.com/go-gl/glfw/v3.3/glfw"
 )
 
@@ -10,6 +11,7 @@ var oldXpos float64
 var oldYpos float64
 var MouseWheelValue float32
 var MouseLook bool
+var bookmarks = Cameras.NewBookmarks()
 
 func handleMouseMove(w *glfw.Window, xpos float64, ypos float64) {
 	log.Printf("Mouse moved: %v,%v", xpos, ypos)
@@ -17,26 +19,44 @@ func handleMouseMove(w *glfw.Window, xpos float64, ypos float64) {
 	ydiff := ypos - oldYpos
 	oldYpos = ypos
 	oldXpos = xpos
-	if MouseLook {
-	camera.Move(8, float32(-xdiff/500))
-	camera.Move(6, float32(-ydiff/500))
-	}
+	sharedCamera.Do(func(camera *Cameras.Camera) {
+		if camera.GroundDragging() {
+			camera.DragGround(float32(xpos), float32(ypos))
+		} else if MouseLook && camera.Mode == Cameras.Museum {
+			//Turn the exhibit with the mouse
+			camera.Arcball(float32(xpos-xdiff), float32(ypos-ydiff), float32(xpos), float32(ypos))
+		} else if MouseLook {
+			camera.Move(Cameras.YawLeft, float32(-xdiff/500))
+			camera.Move(Cameras.PitchUp, float32(-ydiff/500))
+		}
+	})
 
 }
 func handleMouseButton(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mod glfw.ModifierKey) {
 	log.Printf("Got mouse button %v,%v,%v", button, mod, action)
 	//handleKey(w, key, scancode, action, mods)
-	if action == 1 {
-		MouseLook = true
-	}
-	if action == 0 {
-		MouseLook = false
-	}
+	sharedCamera.Do(func(camera *Cameras.Camera) {
+		if action == 1 {
+			//In RTS mode, grab the ground under the cursor instead of looking around
+			if camera.Mode != Cameras.RTS || !camera.BeginGroundDrag(float32(oldXpos), float32(oldYpos)) {
+				MouseLook = true
+			}
+		}
+		if action == 0 {
+			MouseLook = false
+			camera.EndGroundDrag()
+		}
+	})
 }
 
 func handleMouseWheel(w *glfw.Window, xoff float64, yoff float64) {
 	log.Printf("Got mouse wheel %v,%v", xoff, yoff)
 	MouseWheelValue += float32(yoff)
+	if !WantSBS {
+		sharedCamera.Do(func(camera *Cameras.Camera) {
+			camera.ZoomToCursor(float32(oldXpos), float32(oldYpos), float32(yoff))
+		})
+	}
 
 	//handleKey(w, key, scancode, action, mods)
 }
@@ -63,67 +83,61 @@ func handleKey(win *glfw.Window, key glfw.Key, scancode int, action glfw.Action,
 		switchCameraMode()
 	}
 
+	// Shift and a number key saves a bookmark, the number key alone flies back to it
+	if key >= glfw.Key1 && key <= glfw.Key9 && action == glfw.Press {
+		name := string(rune(key)) // GLFW number keys are their ASCII digits
+		if mods == glfw.ModShift {
+			sharedCamera.Do(func(camera *Cameras.Camera) { bookmarks.Save(name, camera) })
+			log.Printf("Saved bookmark %v", name)
+		} else if bookmark, ok := bookmarks.Get(name); ok {
+			sharedCamera.Do(func(camera *Cameras.Camera) { camera.FlyTo(bookmark, 1.5, Cameras.EaseInOut) })
+		}
+	}
+
 	if action == 0 && mods == 0 {
 		keyLatch.Set(key,false)
 	}
 
 
-	camera.Dump()
+	sharedCamera.Do(func(camera *Cameras.Camera) { camera.Dump() })
 
 }
 
 
-func MoveStep(win *glfw.Window, size float32) {
-	
-	//Left
-	if keyLatch.Get(65) {
-		camera.Move(2, 0.2*size)
-	}
-	//Right
-	if keyLatch.Get(68) {
-		camera.Move(3, 0.2*size)
-	}
-	//Up
-	if keyLatch.Get(87) {
-		camera.Move(0, 0.2*size)
-	}
-	//Down
-	if keyLatch.Get(83) {
-		camera.Move(1, 0.2*size)
-	}
-	//Q
-	if keyLatch.Get(81) {
-		camera.Move(8, 0.1*size)
-	}
-	//E
-	if keyLatch.Get(69) {
-		camera.Move(9, 0.1*size)
-
-	}
-	// X
-	if keyLatch.Get(88) {
-		camera.Move(4, 0.1*size)
-	}
-	// space bar
-	if keyLatch.Get(32) {
-		camera.Move(5, 0.1*size)
-	}
-	// R
-	if keyLatch.Get(82) {
-		camera.Move(6, 0.1*size)
-	}
-	// F
-	if keyLatch.Get(70){
-		camera.Move(7, 0.1*size)
-	}
+// Queue the camera's desired velocities from the keys that are held down.  sharedCamera.Update does the moving.
+func MoveStep(win *glfw.Window, speed, turnRate float32) {
+	// W and S
+	setAxis(Cameras.Forward, keyLatch.Get(87), keyLatch.Get(83), speed)
+	// A and D
+	setAxis(Cameras.Left, keyLatch.Get(65), keyLatch.Get(68), speed)
+	// X and space bar
+	setAxis(Cameras.Up, keyLatch.Get(88), keyLatch.Get(32), speed/2)
+	// R and F
+	setAxis(Cameras.PitchUp, keyLatch.Get(82), keyLatch.Get(70), turnRate)
+	// Q and E
+	setAxis(Cameras.YawLeft, keyLatch.Get(81), keyLatch.Get(69), turnRate)
+	// Z and C, in flight mode
+	setAxis(Cameras.RollLeft, keyLatch.Get(90), keyLatch.Get(67), turnRate)
 	// Esc
-	if keyLatch	.Get(256) {
+	if keyLatch.Get(256) {
 		log.Println("Quitting")
 		shutdown()
 		win.SetShouldClose(true)
 	}
 }
 
+// Set the desired velocity along an axis, from the keys for its positive and negative directions
+func setAxis(direction Cameras.Direction, positive, negative bool, speed float32) {
+	velocity := float32(0)
+	if positive {
+		velocity += speed
+	}
+	if negative {
+		velocity -= speed
+	}
+	sharedCamera.SetDesiredVelocity(direction, velocity)
+}
+
 // Make a generic hashmap for key presses
 
 type GenericMap[K comparable, V any] struct {
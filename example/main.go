@@ -42,11 +42,13 @@ var embeddedFS embed.FS
 
 var MainWin *glfw.Window
 var WantSBS bool
+var stereoLayout = Cameras.FullSideBySide
 
 var (
 	// ... other variables ...
 	cameraMode    int
 	camera        *Cameras.Camera
+	sharedCamera  *Cameras.SharedCamera // camera, shared between the movement goroutine and the main thread
 	recordDemo    string
 	switchModeKey glfw.Key = glfw.KeyTab // Default key to switch camera mode
 )
@@ -54,7 +56,16 @@ var (
 // Arrange that main.main runs on main thread.
 func init() {
 	flag.BoolVar(&WantSBS, "sbs", false, "Side by side 3D")
-	flag.IntVar(&cameraMode, "camera-mode", 2, "Set initial camera mode (1: Museum, 2: FPS, 3: RTS)")
+	flag.Func("stereo-layout", "Stereo layout for -sbs: HalfSideBySide, FullSideBySide, TopBottom or Anaglyph", func(name string) error {
+		for _, layout := range []Cameras.StereoLayout{Cameras.HalfSideBySide, Cameras.FullSideBySide, Cameras.TopBottom, Cameras.Anaglyph} {
+			if layout.String() == name {
+				stereoLayout = layout
+				return nil
+			}
+		}
+		return fmt.Errorf("unsupported stereo layout %q", name)
+	})
+	flag.IntVar(&cameraMode, "camera-mode", 2, "Set initial camera mode (1: Museum, 2: FPS, 3: RTS, 4: Flight)")
 	flag.StringVar(&recordDemo, "record-demo", "", "Record a five-second demo GIF (rts or flight) and exit")
 	flag.Parse()
 	runtime.LockOSThread()
@@ -84,7 +95,6 @@ type tree_struct struct {
 
 var winWidth = 180
 var winHeight = 180
-var lasttime float64
 var trees []tree_struct
 
 const groundTileRadius = 15
@@ -100,9 +110,21 @@ func drainChannel(ch chan []byte) {
 }
 
 func switchCameraMode() {
-	cameraMode = (cameraMode % 3) + 1
-	camera.SetMode(cameraMode)
-	log.Printf("Switched to camera mode: %d", cameraMode)
+	cameraMode = (cameraMode % 4) + 1
+	// Blend into the new mode's view, instead of jumping
+	sharedCamera.Do(func(camera *Cameras.Camera) {
+		camera.BlendToMode(Cameras.Mode(cameraMode), 0.5, Cameras.EaseInOut)
+		log.Printf("Switched to camera mode: %v", camera.Mode)
+	})
+}
+
+// moveCamera moves the camera at a steady rate, on its own goroutine.  Everything else queues commands for it, or reads its frames
+func moveCamera() {
+	last := time.Now()
+	for now := range time.Tick(5 * time.Millisecond) {
+		sharedCamera.Update(float32(now.Sub(last).Seconds()))
+		last = now
+	}
 }
 
 func main() {
@@ -125,7 +147,7 @@ func main() {
 		go drainChannel(err)
 	}
 
-	camera = Cameras.New(cameraMode)
+	camera = Cameras.New(Cameras.Mode(cameraMode))
 	camera.SetPosition(12, 14, 2)
 	camera.SetUp(0, 0, 1)
 	camera.SetIPD(1.0)
@@ -135,6 +157,7 @@ func main() {
 	camera.Near = 1.0
 	camera.Far = 100
 	camera.IPD = 1.0
+	sharedCamera = Cameras.NewSharedCamera(camera)
 
 	currentDir, _ := os.Getwd()
 	for _, commandStr := range launchList {
@@ -215,27 +238,22 @@ func main() {
 		return
 	}
 
-	go func() {
-		for {
-			scale := float32(0.5) //Multiplier for the camera movement
-			//Move the camera
-			MoveStep(win, scale)
-			time.Sleep(25 * time.Millisecond)
-		}
-	}()
+	go moveCamera()
 	joystick.Setup_joystick()
 	/*
 		messages.Register("JoystickY", "JoystickY", func(name , id string, args interface{}) {
 			amount := args.(float64)
-			camera.Move(0, float32(amount))
+			camera.Move(Cameras.Forward, float32(amount))
 		})
 		messages.Register("JoystickX", "JoystickX", func(name , id string, args interface{}) {
 			amount := args.(float64)
-			camera.Move(2, float32(amount))
+			camera.Move(Cameras.Left, float32(amount))
 		})
 	*/
 	for !win.ShouldClose() {
 		joystick.DoJoystick()
+		//Queue the keys that are held down.  moveCamera does the moving
+		MoveStep(win, 4, 2)
 		mode := glfw.GetPrimaryMonitor().GetVideoMode()
 		screenW, screenH := mode.Width, mode.Height
 		if screenW >= screenH*2-1 {
@@ -292,13 +310,21 @@ func gfxMain(win *glfw.Window, state *State) {
 		angle += elapsed
 		state.Angle = angle
 
-		viewMatrix := camera.ViewMatrix()
-
 		if WantSBS {
-			RenderStereoFrame(state, viewMatrix)
+			RenderStereoFrame(state)
 		} else {
-			projectionMatrix := mgl32.Perspective(camera.FOV, float32(camera.Screenwidth)/float32(camera.Screenheight), camera.Near, camera.Far)
-			RenderFrame(state, viewMatrix, projectionMatrix)
+			width, height := win.GetSize()
+			sharedCamera.Do(func(camera *Cameras.Camera) {
+				camera.Screenwidth = float32(width)
+				camera.Screenheight = float32(height)
+			})
+			//All the matrices for this frame, from the same moment
+			frame := sharedCamera.Frame()
+			if frame.ProjectionErr != nil {
+				//The window has no size, for example while it is being minimised
+				return
+			}
+			RenderFrame(state, frame.View, frame.Projection)
 		}
 		win.SwapBuffers()
 
@@ -306,28 +332,35 @@ func gfxMain(win *glfw.Window, state *State) {
 
 }
 
-func RenderStereoFrame(state *State, discard mgl32.Mat4) {
+func RenderStereoFrame(state *State) {
 	if MouseWheelValue == 0 {
 		MouseWheelValue = 0.01
 	}
-	camera.SetIPD(MouseWheelValue)
 	//get window width and height
 	width, height := MainWin.GetSize()
-	camera.Screenwidth = float32(width) / 2
-	camera.Screenheight = float32(height)
-	// Set viewport to left half of window
-	gl.Viewport(0, 0, int32(width/2), int32(height))
-	LeftviewMatrix := camera.LeftEyeViewMatrix()
-	//fmt.Println("Left Eye View Matrix", LeftviewMatrix)
-	LeftEyeFrustrum := camera.LeftEyeFrustrum()
-	RenderFrame(state, LeftviewMatrix, LeftEyeFrustrum)
-	//Set viewport to right half of window
-	gl.Viewport(int32(width/2), 0, int32(width/2), int32(height))
-	viewMatrix := camera.RightEyeViewMatrix()
-	//fmt.Println("Right Eye View Matrix", viewMatrix)
-	RightProjectionMatrix := camera.RightEyeFrustrum()
-	RenderFrame(state, viewMatrix, RightProjectionMatrix)
+	//Both eyes' matrices and viewports, from the same moment
+	var views [2]Cameras.StereoView
+	var err error
+	sharedCamera.Do(func(camera *Cameras.Camera) {
+		camera.SetIPD(MouseWheelValue)
+		camera.Screenwidth = float32(width)
+		camera.Screenheight = float32(height)
+		views, err = camera.StereoViews(stereoLayout)
+	})
+	if err != nil {
+		return
+	}
+	for i, view := range views {
+		if i > 0 && view.Viewport == views[0].Viewport {
+			//The eyes overlap, so the second eye must not be hidden by the first
+			gl.Clear(gl.DEPTH_BUFFER_BIT)
+		}
+		gl.Viewport(int32(view.Viewport.X), int32(view.Viewport.Y), int32(view.Viewport.Width), int32(view.Viewport.Height))
+		gl.ColorMask(view.ColorMask.Red, view.ColorMask.Green, view.ColorMask.Blue, view.ColorMask.Alpha)
+		RenderFrame(state, view.View, view.Projection)
+	}
 	//Set viewport to whole window
+	gl.ColorMask(true, true, true, true)
 	gl.Viewport(0, 0, int32(width), int32(height))
 }
 
@@ -347,9 +380,19 @@ func RenderFrame(state *State, viewMatrix mgl32.Mat4, projectionMatrix mgl32.Mat
 
 	gl.Disable(gl.BLEND)
 
+	//The eye position, from the view matrix, so that drawing never touches the shared camera
+	eyePosition := viewMatrix.Inv().Col(3).Vec3()
+
+	//Skip anything the camera cannot see
+	frustum := Cameras.NewFrustum(projectionMatrix.Mul4(viewMatrix))
+
 	//Draw the ground layer
 	for i := -groundTileRadius; i <= groundTileRadius; i++ {
 		for j := -groundTileRadius; j <= groundTileRadius; j++ {
+			tileCenter := mgl32.Vec3{float32(i) * 2, float32(j) * 2, 0}
+			if !frustum.IntersectsAABB(tileCenter.Sub(mgl32.Vec3{1, 1, 1}), tileCenter.Add(mgl32.Vec3{1, 1, 1})) {
+				continue
+			}
 
 			model := mgl32.Ident4()
 			model = model.Mul4(mgl32.Translate3D(float32(i)*2, float32(j)*2, 0))
@@ -371,12 +414,12 @@ func RenderFrame(state *State, viewMatrix mgl32.Mat4, projectionMatrix mgl32.Mat
 	sort.Slice(trees, func(i, j int) bool {
 		//Subtract the camera position from the tree position
 		treeVeci := mgl32.Vec3{trees[i].X, trees[i].Y, trees[i].Z}
-		cameraVeci := mgl32.Vec3{camera.Position.X(), camera.Position.Y(), camera.Position.Z()}
+		cameraVeci := eyePosition
 		treeDisVeci := treeVeci.Sub(cameraVeci)
 		treeDisi := treeDisVeci.Len()
 
 		treeVecj := mgl32.Vec3{trees[j].X, trees[j].Y, trees[j].Z}
-		cameraVecj := mgl32.Vec3{camera.Position.X(), camera.Position.Y(), camera.Position.Z()}
+		cameraVecj := eyePosition
 		treeDisVecj := treeVecj.Sub(cameraVecj)
 		treeDisj := treeDisVecj.Len()
 
@@ -384,11 +427,14 @@ func RenderFrame(state *State, viewMatrix mgl32.Mat4, projectionMatrix mgl32.Mat
 	})
 	// Draw the trees
 	for _, tree := range trees {
+		if !frustum.IntersectsSphere(mgl32.Vec3{tree.X, tree.Y, 1.8}, 1.8*math.Sqrt2) {
+			continue
+		}
 		model := mgl32.Ident4()
 
 		model = model.Mul4(mgl32.Translate3D(tree.X, tree.Y, 1.8))
 
-		directionToCamera := camera.Position.Sub(mgl32.Vec3{tree.X, tree.Y, 0})
+		directionToCamera := eyePosition.Sub(mgl32.Vec3{tree.X, tree.Y, 0})
 		billboardAngle := float32(math.Atan2(float64(directionToCamera.Y()), float64(directionToCamera.X()))) + PI/2
 		model = model.Mul4(mgl32.HomogRotate3DZ(billboardAngle))
 		model = model.Mul4(mgl32.HomogRotate3DY(PI))
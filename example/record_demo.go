@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 
+	Cameras "github.com/donomii/sceneCamera"
 	"github.com/go-gl/gl/v3.2-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
 	"github.com/go-gl/mathgl/mgl32"
@@ -111,50 +112,41 @@ func configureRTSDemoCamera(progress float32) {
 	position := mapOffset.Add(mgl32.Vec3{11, -14, 13})
 	target := mapOffset.Add(mgl32.Vec3{0, 0, 0.8})
 
-	camera.SetMode(3)
+	camera.SetMode(Cameras.RTS)
 	camera.SetUp(0, 0, 1)
 	camera.SetPosition(position.X(), position.Y(), position.Z())
 	camera.LookAt(target.X(), target.Y(), target.Z())
 }
 
-func configureFlightDemoCamera(progress float32) {
-	position := flightPosition(progress)
-	lookAheadDistance := 0.22 - 0.17*progress
-	lookAheadProgress := min(progress+lookAheadDistance, 1)
-	direction := flightPosition(lookAheadProgress).Sub(position)
-	if direction.Len() == 0 {
-		direction = position.Sub(flightPosition(max(progress-0.035, 0)))
-	}
-	direction = direction.Normalize()
-	bankAngle := 0.32 * float32(math.Sin(float64(progress*2*float32(math.Pi))))
-	up := mgl32.QuatRotate(bankAngle, direction).Rotate(mgl32.Vec3{0, 0, 1})
-	target := position.Add(direction.Mul(8))
-
-	camera.SetMode(2)
-	camera.SetUp(up.X(), up.Y(), up.Z())
-	camera.SetPosition(position.X(), position.Y(), position.Z())
-	camera.LookAt(target.X(), target.Y(), target.Z())
-}
+// flightPath is the scripted flight demo: a dive toward the trees, levelling out, then banking through a turn.
+var flightPath = newFlightPath()
 
-func flightPosition(progress float32) mgl32.Vec3 {
-	x := -15 + 27*progress
-	y := -13 + 23*progress + 3*float32(math.Sin(float64(progress*2*float32(math.Pi))))
-	diveProgress := smoothStep(0, 0.62, progress)
-	z := 17 + (4.2-17)*diveProgress
-	return mgl32.Vec3{x, y, z}
+func newFlightPath() *Cameras.CameraPath {
+	fov := mgl32.DegToRad(52)
+	keyframe := func(time float32, position, target, up mgl32.Vec3) Cameras.Keyframe {
+		return Cameras.Keyframe{Time: time, Pose: Cameras.Pose{Position: position, Target: target, Up: up, FOV: fov}}
+	}
+	return Cameras.NewCameraPath(
+		keyframe(0.00, mgl32.Vec3{-15.0, -13.0, 17.0}, mgl32.Vec3{-10.5, -7.0, 14.2}, mgl32.Vec3{0, 0, 1}),
+		keyframe(0.84, mgl32.Vec3{-10.5, -6.6, 14.7}, mgl32.Vec3{-5.7, -2.8, 9.6}, mgl32.Vec3{0.12, -0.17, 0.98}),
+		keyframe(1.68, mgl32.Vec3{-6.0, -2.7, 9.9}, mgl32.Vec3{-0.4, -1.2, 4.4}, mgl32.Vec3{0.03, -0.20, 0.98}),
+		keyframe(2.52, mgl32.Vec3{-1.5, -1.5, 5.5}, mgl32.Vec3{5.9, 0.2, 2.9}, mgl32.Vec3{0, 0, 1}),
+		keyframe(3.36, mgl32.Vec3{3.0, -0.3, 4.2}, mgl32.Vec3{9.5, 4.4, 4.2}, mgl32.Vec3{-0.16, 0.22, 0.96}),
+		keyframe(4.20, mgl32.Vec3{7.5, 3.6, 4.2}, mgl32.Vec3{12.3, 10.0, 4.2}, mgl32.Vec3{-0.22, 0.16, 0.96}),
+		keyframe(5.04, mgl32.Vec3{12.0, 10.0, 4.2}, mgl32.Vec3{16.3, 16.7, 4.2}, mgl32.Vec3{0, 0, 1}),
+	)
 }
 
-func smoothStep(edgeStart, edgeEnd, value float32) float32 {
-	position := min(max((value-edgeStart)/(edgeEnd-edgeStart), 0), 1)
-	return position * position * (3 - 2*position)
+func configureFlightDemoCamera(progress float32) {
+	camera.SetMode(Cameras.Flight)
+	flightPath.Sample(progress*flightPath.Duration(), camera)
 }
 
 func renderDemoFrame(win *glfw.Window, state *State) {
 	width, height := win.GetFramebufferSize()
 	gl.Viewport(0, 0, int32(width), int32(height))
 	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
-	projection := mgl32.Perspective(camera.FOV, float32(width)/float32(height), camera.Near, camera.Far)
-	RenderFrame(state, camera.ViewMatrix(), projection)
+	RenderFrame(state, camera.ViewMatrix(), camera.ProjectionMatrix())
 	gl.Finish()
 }
 
@@ -0,0 +1,71 @@
+package sceneCamera
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Eye selects the mono view, or one of the two stereo views.
+type Eye int
+
+// Eyes.  MonoEye uses ViewMatrix and ProjectionMatrix, the others use the matching stereo functions.
+const (
+	MonoEye Eye = iota
+	LeftEye
+	RightEye
+)
+
+// String returns the name of the eye.
+func (e Eye) String() string {
+	switch e {
+	case MonoEye:
+		return "Mono"
+	case LeftEye:
+		return "Left"
+	case RightEye:
+		return "Right"
+	}
+	return fmt.Sprintf("Eye(%d)", int(e))
+}
+
+// EyeViewMatrix returns the view matrix for the eye: ViewMatrix, LeftEyeViewMatrix or RightEyeViewMatrix.
+func (c *Camera) EyeViewMatrix(eye Eye) mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).views[eye.index()]
+}
+
+// EyeProjectionMatrix returns the projection matrix for the eye: ProjectionMatrix, LeftEyeFrustum or RightEyeFrustum.
+func (c *Camera) EyeProjectionMatrix(eye Eye) mgl32.Mat4 {
+	switch eye {
+	case LeftEye:
+		return c.LeftEyeFrustum()
+	case RightEye:
+		return c.RightEyeFrustum()
+	}
+	return c.ProjectionMatrix()
+}
+
+// EyeViewProjectionMatrix returns the eye's projection matrix times its view matrix, which takes world space to clip space.
+// For LeftEye and RightEye it panics if the eye frustum cannot be built, as LeftEyeFrustum does.
+func (c *Camera) EyeViewProjectionMatrix(eye Eye) mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.combinedCache(cache, eye.index()).viewProjection
+}
+
+// EyeInverseViewProjectionMatrix returns the inverse of EyeViewProjectionMatrix, which takes clip space back to world space.
+func (c *Camera) EyeInverseViewProjectionMatrix(eye Eye) mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.combinedCache(cache, eye.index()).inverseViewProjection
+}
+
+// index returns the eye as an index into per-eye arrays.  Unknown eyes are treated as MonoEye, as EyeViewMatrix does.
+func (e Eye) index() Eye {
+	if e == LeftEye || e == RightEye {
+		return e
+	}
+	return MonoEye
+}
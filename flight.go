@@ -0,0 +1,101 @@
+package sceneCamera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// SetAutoLevel sets the rate, in radians per second, at which Update rolls a Flight mode camera back towards level.  Zero turns auto-levelling off.
+func (c *Camera) SetAutoLevel(rate float32) {
+	c.AutoLevelRate = rate
+}
+
+// flightController moves and turns the camera in its own axes.
+// The orientation quaternion is the camera's true attitude, so pitching past vertical loops the camera, and rolling can turn it upside down.
+type flightController struct{}
+
+func (flightController) Enter(c *Camera) {}
+
+func (flightController) Supports(direction Direction) bool {
+	return direction >= Forward && direction <= RollRight
+}
+
+func (flightController) Move(c *Camera, direction Direction, amount float32) {
+	toWorld := c.Orientation.Conjugate()
+	forward := toWorld.Rotate(mgl32.Vec3{0, 0, -1})
+	right := toWorld.Rotate(mgl32.Vec3{1, 0, 0})
+	up := toWorld.Rotate(mgl32.Vec3{0, 1, 0})
+
+	switch direction {
+	case Forward:
+		c.Position = c.Position.Add(forward.Mul(amount))
+	case Backward:
+		c.Position = c.Position.Sub(forward.Mul(amount))
+	case Left:
+		c.Position = c.Position.Sub(right.Mul(amount))
+	case Right:
+		c.Position = c.Position.Add(right.Mul(amount))
+	case Up:
+		c.Position = c.Position.Add(up.Mul(amount))
+	case Down:
+		c.Position = c.Position.Sub(up.Mul(amount))
+	case PitchUp:
+		c.rotateLocal(amount, mgl32.Vec3{1, 0, 0})
+	case PitchDown:
+		c.rotateLocal(-amount, mgl32.Vec3{1, 0, 0})
+	case YawLeft:
+		c.rotateLocal(amount, mgl32.Vec3{0, 1, 0})
+	case YawRight:
+		c.rotateLocal(-amount, mgl32.Vec3{0, 1, 0})
+	case RollLeft:
+		//The camera looks down -Z, so a positive turn about +Z lowers the left side
+		c.rotateLocal(amount, mgl32.Vec3{0, 0, 1})
+	case RollRight:
+		c.rotateLocal(-amount, mgl32.Vec3{0, 0, 1})
+	}
+	c.syncFromOrientation()
+}
+
+// rotateLocal turns the camera by angle radians about an axis in camera space.
+func (c *Camera) rotateLocal(angle float32, axis mgl32.Vec3) {
+	//Orientation rotates world space into camera space, so a turn in camera space is applied on the left, inverted
+	c.Orientation = mgl32.QuatRotate(-angle, axis).Mul(c.Orientation).Normalize()
+}
+
+// syncFromOrientation points the target and up vector along the orientation, keeping the target at the same distance.
+func (c *Camera) syncFromOrientation() {
+	distance := c.Target.Sub(c.Position).Len()
+	if distance == 0 {
+		distance = 1
+	}
+	pose := poseFromOrientation(c.Position, c.Orientation, distance, c.FOV)
+	c.Target = pose.Target
+	c.Up = pose.Up
+}
+
+// Update rolls the camera towards level, by at most AutoLevelRate*dt radians, unless the camera is being rolled.
+// Level means the camera's up vector is as close to GroundPlaneNormal as the view direction allows.  Pointing straight along the normal has no level roll, so nothing happens.
+func (flightController) Update(c *Camera, dt float32) {
+	rollAxis, _, _ := directionAxis(RollLeft)
+	if c.AutoLevelRate <= 0 || c.desiredVelocity[rollAxis] != 0 {
+		return
+	}
+	toWorld := c.Orientation.Conjugate()
+	forward := toWorld.Rotate(mgl32.Vec3{0, 0, -1})
+	up := toWorld.Rotate(mgl32.Vec3{0, 1, 0})
+	level := c.GroundPlaneNormal.Sub(forward.Mul(c.GroundPlaneNormal.Dot(forward)))
+	if level.Len() < 1e-4 {
+		return
+	}
+	level = level.Normalize()
+
+	//The roll angle from up to level, positive when level is to the camera's left
+	bank := float32(math.Atan2(float64(up.Cross(level).Dot(forward.Mul(-1))), float64(up.Dot(level))))
+	step := c.AutoLevelRate * dt
+	bank = min(max(bank, -step), step)
+	if bank != 0 {
+		c.rotateLocal(bank, mgl32.Vec3{0, 0, 1})
+		c.syncFromOrientation()
+	}
+}
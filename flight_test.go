@@ -0,0 +1,124 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// bankAngle returns how far the camera is rolled away from level, in radians.
+func bankAngle(c *Camera) float32 {
+	level := ProjectPlane(c.ForwardsVector(), c.GroundPlaneNormal).Normalize()
+	return float32(math.Acos(float64(mgl32.Clamp(c.UpwardsVector().Dot(level), -1, 1))))
+}
+
+func TestFlightLocalRotations(t *testing.T) {
+	tests := []struct {
+		direction Direction
+		forward   mgl32.Vec3
+		up        mgl32.Vec3
+	}{
+		{PitchUp, mgl32.Vec3{0, 0, 1}, mgl32.Vec3{-1, 0, 0}},
+		{PitchDown, mgl32.Vec3{0, 0, -1}, mgl32.Vec3{1, 0, 0}},
+		{YawLeft, mgl32.Vec3{0, 1, 0}, mgl32.Vec3{0, 0, 1}},
+		{YawRight, mgl32.Vec3{0, -1, 0}, mgl32.Vec3{0, 0, 1}},
+		{RollLeft, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{0, 1, 0}},
+		{RollRight, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{0, -1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.direction.String(), func(t *testing.T) {
+			camera := flyingPose.camera()
+			camera.Move(tt.direction, math.Pi/2)
+			assertVec3Near(t, camera.ForwardsVector(), tt.forward, 1e-4)
+			assertVec3Near(t, camera.UpwardsVector(), tt.up, 1e-4)
+			assertFloat(t, camera.TargetVector().Len(), 5)
+			if lookAt := mgl32.Mat4ToQuat(mgl32.LookAtV(camera.Position, camera.Target, camera.Up)); !camera.Orientation.OrientationEqualThreshold(lookAt, 1e-4) {
+				t.Errorf("expected the target and up vector to match orientation %v, got %v", camera.Orientation, lookAt)
+			}
+		})
+	}
+}
+
+func TestFlightTurnsInCameraAxes(t *testing.T) {
+	//After banking onto the left wing, pulling up turns left, not up
+	camera := flyingPose.camera()
+	camera.Move(RollLeft, math.Pi/2)
+	camera.Move(PitchUp, math.Pi/2)
+	assertVec3(t, camera.ForwardsVector(), mgl32.Vec3{0, 1, 0})
+
+	//Translation is in camera axes too
+	camera.Move(Up, 2)
+	assertVec3(t, camera.Position, mgl32.Vec3{-2, 0, 10})
+}
+
+func TestFlightLoopAndInvertedFlight(t *testing.T) {
+	camera := flyingPose.camera()
+	const steps = 100
+	for i := 0; i < steps; i++ {
+		camera.Move(PitchUp, math.Pi/steps)
+		camera.Move(Forward, 0.1)
+	}
+	//Half a loop leaves the camera flying back the way it came, upside down
+	assertVec3Near(t, camera.ForwardsVector(), mgl32.Vec3{-1, 0, 0}, 1e-4)
+	assertVec3Near(t, camera.UpwardsVector(), mgl32.Vec3{0, 0, -1}, 1e-4)
+	if camera.Position.Z() <= 10 {
+		t.Errorf("expected the loop to climb, got %v", camera.Position)
+	}
+
+	//Inverted, moving up moves towards the ground
+	height := camera.Position.Z()
+	camera.Move(Up, 1)
+	assertFloat(t, camera.Position.Z(), height-1)
+
+	for i := 0; i < steps; i++ {
+		camera.Move(PitchUp, math.Pi/steps)
+		camera.Move(Forward, 0.1)
+	}
+	assertVec3Near(t, camera.ForwardsVector(), mgl32.Vec3{1, 0, 0}, 1e-4)
+	assertVec3Near(t, camera.UpwardsVector(), mgl32.Vec3{0, 0, 1}, 1e-4)
+	assertFloat(t, camera.Orientation.Len(), 1)
+}
+
+func TestFlightAutoLevel(t *testing.T) {
+	camera := flyingPose.camera()
+	camera.Move(RollRight, 0.5)
+	camera.Move(PitchUp, 0.3)
+	camera.Update(1)
+	bank := bankAngle(camera)
+	if bank < 0.4 {
+		t.Fatalf("expected the camera to stay banked without auto-level, got %v", bank)
+	}
+
+	camera.SetAutoLevel(0.25)
+	camera.Update(1)
+	assertFloat(t, bankAngle(camera), bank-0.25)
+
+	//Auto-level waits while the pilot is rolling
+	camera.SetDesiredVelocity(RollLeft, 0.001)
+	camera.TurnAcceleration = 0
+	camera.Damping = 0
+	camera.Update(1)
+	if bankAngle(camera) < bank-0.26 {
+		t.Errorf("expected no auto-level while rolling, got bank %v", bankAngle(camera))
+	}
+	camera.ClearDesiredVelocity()
+
+	camera.Update(2)
+	assertFloat(t, bankAngle(camera), 0)
+	//Only roll is levelled, so the climb is kept
+	if camera.ForwardsVector().Z() < 0.25 {
+		t.Errorf("expected auto-level to keep the pitch, got forward %v", camera.ForwardsVector())
+	}
+}
+
+func TestFlightAutoLevelStraightDown(t *testing.T) {
+	camera := flyingPose.camera()
+	camera.SetAutoLevel(1)
+	camera.Move(PitchDown, math.Pi/2)
+	before := camera.Orientation
+	camera.Update(1)
+	if camera.Orientation != before {
+		t.Errorf("expected no auto-level looking along the ground normal")
+	}
+}
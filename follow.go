@@ -0,0 +1,141 @@
+package sceneCamera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Subject is the object that a Follow mode camera follows.
+type Subject struct {
+	Position mgl32.Vec3 //The position of the subject in world space
+	Forward  mgl32.Vec3 //The direction the subject faces.  The camera sits behind it
+	Velocity mgl32.Vec3 //The velocity of the subject, in world units per second.  Used for look-ahead
+}
+
+// FollowOffset is where a Follow mode camera sits, relative to its subject.
+type FollowOffset struct {
+	Distance float32 `json:"distance"` //How far behind the subject, along the ground
+	Height   float32 `json:"height"`   //How far above the subject, along GroundPlaneNormal
+	Angle    float32 `json:"angle"`    //How far round the subject, in radians.  Zero is directly behind, positive angles swing round to the subject's left
+}
+
+// SetFollowSubject sets the subject that a Follow mode camera follows.  Call it every frame, before Update, as the subject moves.
+// The first subject after entering Follow mode is jumped to immediately; after that the camera follows with spring lag.  In other modes the subject is ignored.
+func (c *Camera) SetFollowSubject(subject Subject) {
+	if f, ok := c.activeController().(*followController); ok {
+		f.subject = subject
+		if !f.active {
+			f.active = true
+			f.snap = true
+		}
+	}
+}
+
+// SetFollowOffset sets where a Follow mode camera sits, relative to its subject.
+func (c *Camera) SetFollowOffset(distance, height, angle float32) {
+	c.FollowOffset = FollowOffset{Distance: distance, Height: height, Angle: angle}
+}
+
+// SnapToSubject makes the next Update jump straight to the subject, without lag.  Use it when the subject teleports.
+func (c *Camera) SnapToSubject() {
+	if f, ok := c.activeController().(*followController); ok {
+		f.snap = true
+	}
+}
+
+// followController keeps the camera behind a moving subject, and holds the subject and springs for one camera.
+type followController struct {
+	active         bool       //True once a subject has been set
+	snap           bool       //Jump to the subject on the next update
+	subject        Subject    //The latest subject pose
+	velocity       mgl32.Vec3 //The velocity of the position spring
+	lookAt         mgl32.Vec3 //The smoothed point the camera looks at
+	lookAtVelocity mgl32.Vec3 //The velocity of the look spring
+}
+
+func (f *followController) Enter(c *Camera) {}
+func (f *followController) Exit(c *Camera)  {}
+
+func (f *followController) Supports(direction Direction) bool {
+	return direction >= Forward && direction <= Down
+}
+
+// Move changes the follow offset: Forward and Backward move closer and further, Left and Right swing round the subject, and Up and Down raise and lower the camera.
+func (f *followController) Move(c *Camera, direction Direction, amount float32) {
+	switch direction {
+	case Forward:
+		c.FollowOffset.Distance = max(c.FollowOffset.Distance-amount, 0)
+	case Backward:
+		c.FollowOffset.Distance += amount
+	case Left:
+		c.FollowOffset.Angle += amount
+	case Right:
+		c.FollowOffset.Angle -= amount
+	case Up:
+		c.FollowOffset.Height += amount
+	case Down:
+		c.FollowOffset.Height -= amount
+	}
+}
+
+// goal returns where the camera should be, and the point it should look at, for the current subject and offset.
+func (f *followController) goal(c *Camera) (position, lookAt mgl32.Vec3) {
+	subject := f.subject
+	up := c.GroundPlaneNormal.Normalize()
+	//Behind the subject, along the ground
+	alongGround := func(v mgl32.Vec3) mgl32.Vec3 { return v.Sub(up.Mul(v.Dot(up))) }
+	behind := alongGround(subject.Forward).Mul(-1)
+	if behind.Len() < 1e-6 {
+		//The subject has no heading along the ground, so stay on the side the camera is already on
+		behind = alongGround(c.Position.Sub(subject.Position))
+		if behind.Len() < 1e-6 {
+			behind = ProjectPlane(up, mgl32.Vec3{1, 0, 0})
+		}
+	}
+	behind = mgl32.QuatRotate(-c.FollowOffset.Angle, up).Rotate(behind.Normalize())
+
+	position = subject.Position.Add(behind.Mul(c.FollowOffset.Distance)).Add(up.Mul(c.FollowOffset.Height))
+	lookAt = subject.Position.Add(subject.Velocity.Mul(c.FollowLookAhead))
+	return position, lookAt
+}
+
+// Update moves the camera towards its goal with critically damped springs, so it catches up as fast as possible without overshooting.
+func (f *followController) Update(c *Camera, dt float32) {
+	if !f.active {
+		return
+	}
+	goal, lookAt := f.goal(c)
+	if f.snap {
+		f.snap = false
+		c.Position = goal
+		f.lookAt = lookAt
+		f.velocity = mgl32.Vec3{}
+		f.lookAtVelocity = mgl32.Vec3{}
+	} else {
+		c.Position, f.velocity = criticallyDamped(c.Position, f.velocity, goal, c.FollowLag, dt)
+		f.lookAt, f.lookAtVelocity = criticallyDamped(f.lookAt, f.lookAtVelocity, lookAt, c.FollowLookLag, dt)
+	}
+
+	c.Up = c.GroundPlaneNormal
+	if f.lookAt.Sub(c.Position).Len() < 1e-6 {
+		return
+	}
+	c.LookAt(f.lookAt.X(), f.lookAt.Y(), f.lookAt.Z())
+}
+
+// criticallyDamped moves a value towards a goal as a critically damped spring, over dt seconds.
+// The lag is roughly the time taken to close most of the gap.  A lag of zero or less jumps straight to the goal.
+func criticallyDamped(value, velocity, goal mgl32.Vec3, lag, dt float32) (mgl32.Vec3, mgl32.Vec3) {
+	if lag <= 0 {
+		return goal, mgl32.Vec3{}
+	}
+	//The exact solution of x'' = -omega^2 x - 2 omega x', for the offset x from the goal
+	omega := 2 / lag
+	offset := value.Sub(goal)
+	decay := float32(math.Exp(float64(-omega * dt)))
+	temp := velocity.Add(offset.Mul(omega)).Mul(dt)
+	velocity = velocity.Sub(temp.Mul(omega)).Mul(decay)
+	offset = offset.Add(temp).Mul(decay)
+	return goal.Add(offset), velocity
+}
@@ -0,0 +1,118 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// testSubject is a subject at (10, 0, 0) heading along +X.  With the default FollowOffset, the camera follows 6 behind and 2 above it.
+var testSubject = Subject{Position: mgl32.Vec3{10, 0, 0}, Forward: mgl32.Vec3{1, 0, 0}}
+
+func TestFollowSnapsToFirstSubject(t *testing.T) {
+	camera := New(Follow)
+	camera.SetFollowSubject(testSubject)
+	camera.Update(0.01)
+	assertVec3(t, camera.Position, mgl32.Vec3{4, 0, 2})
+	assertVec3(t, camera.Target, mgl32.Vec3{10, 0, 0})
+	assertVec3(t, camera.Up, camera.GroundPlaneNormal)
+}
+
+func TestFollowOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   FollowOffset
+		expected mgl32.Vec3
+	}{
+		{"behind", FollowOffset{Distance: 6, Height: 2}, mgl32.Vec3{4, 0, 2}},
+		{"left", FollowOffset{Distance: 6, Height: 2, Angle: math.Pi / 2}, mgl32.Vec3{10, 6, 2}},
+		{"right", FollowOffset{Distance: 6, Height: 2, Angle: -math.Pi / 2}, mgl32.Vec3{10, -6, 2}},
+		{"front", FollowOffset{Distance: 3, Height: 1, Angle: math.Pi}, mgl32.Vec3{13, 0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			camera := New(Follow)
+			camera.SetFollowSubject(testSubject)
+			camera.Update(0.01)
+			camera.FollowOffset = tt.offset
+			camera.SnapToSubject()
+			camera.Update(0.01)
+			assertVec3Near(t, camera.Position, tt.expected, 1e-4)
+		})
+	}
+}
+
+func TestFollowMoveChangesOffset(t *testing.T) {
+	camera := New(Follow)
+	camera.SetFollowSubject(testSubject)
+	camera.Update(0.01)
+	camera.Move(Backward, 2)
+	camera.Move(Up, 1)
+	camera.Move(Left, 0.5)
+	expected := FollowOffset{Distance: 8, Height: 3, Angle: 0.5}
+	if camera.FollowOffset != expected {
+		t.Errorf("expected offset %+v, got %+v", expected, camera.FollowOffset)
+	}
+	camera.Move(Forward, 100)
+	if camera.FollowOffset.Distance != 0 {
+		t.Errorf("expected the distance to stop at zero, got %v", camera.FollowOffset.Distance)
+	}
+}
+
+func TestFollowSpringLag(t *testing.T) {
+	camera := New(Follow)
+	camera.SetFollowSubject(testSubject)
+	camera.Update(0.01)
+	camera.FollowLag = 0.5
+	//The subject jumps forward, and the camera follows without overshooting
+	camera.SetFollowSubject(Subject{Position: mgl32.Vec3{20, 0, 0}, Forward: mgl32.Vec3{1, 0, 0}})
+	goal := mgl32.Vec3{14, 0, 2}
+	camera.Update(0.05)
+	if camera.Position.X() <= 4 || camera.Position.X() >= 10 {
+		t.Errorf("expected the camera to lag behind, got %v", camera.Position)
+	}
+	for i := 0; i < 100; i++ {
+		camera.Update(0.05)
+		if camera.Position.X() > goal.X()+1e-4 {
+			t.Fatalf("expected no overshoot, got %v", camera.Position)
+		}
+	}
+	assertVec3(t, camera.Position, goal)
+	assertVec3(t, camera.Target, mgl32.Vec3{20, 0, 0})
+
+	camera.FollowLag = 0
+	camera.FollowLookLag = 0
+	camera.SetFollowSubject(Subject{Position: mgl32.Vec3{0, 10, 0}, Forward: mgl32.Vec3{0, 1, 0}})
+	camera.Update(0.01)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 4, 2})
+}
+
+func TestFollowLookAhead(t *testing.T) {
+	camera := New(Follow)
+	camera.SetFollowSubject(testSubject)
+	camera.Update(0.01)
+	camera.FollowLookAhead = 0.5
+	subject := Subject{Position: mgl32.Vec3{10, 0, 0}, Forward: mgl32.Vec3{1, 0, 0}, Velocity: mgl32.Vec3{0, 4, 0}}
+	camera.SetFollowSubject(subject)
+	for i := 0; i < 100; i++ {
+		camera.Update(0.05)
+	}
+	//The camera looks 2 units ahead along the velocity, without the distance to the look point changing the offset
+	assertVec3(t, camera.Position, mgl32.Vec3{4, 0, 2})
+	lookAt := mgl32.Vec3{10, 2, 0}
+	assertVec3(t, camera.ForwardsVector(), lookAt.Sub(camera.Position).Normalize())
+}
+
+func TestCriticallyDampedIsFrameRateIndependent(t *testing.T) {
+	goal := mgl32.Vec3{10, 0, 0}
+	coarse, coarseVelocity := criticallyDamped(mgl32.Vec3{}, mgl32.Vec3{}, goal, 0.3, 0.2)
+	fine, fineVelocity := mgl32.Vec3{}, mgl32.Vec3{}
+	for i := 0; i < 20; i++ {
+		fine, fineVelocity = criticallyDamped(fine, fineVelocity, goal, 0.3, 0.01)
+	}
+	assertVec3(t, fine, coarse)
+	if fineVelocity.Sub(coarseVelocity).Len() > 1e-3 {
+		t.Errorf("expected velocity %v, got %v", coarseVelocity, fineVelocity)
+	}
+}
@@ -0,0 +1,132 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Frustum is the volume of world space that a camera can see, held as six planes.
+// Each plane is stored as (a, b, c, d), where (a, b, c) is a unit normal pointing into the frustum, so a point p is inside the plane when a*p.x + b*p.y + c*p.z + d >= 0.
+//
+// A plane with a zero normal, such as the far plane of an infinite projection, contains every point.
+type Frustum struct {
+	Planes [6]mgl32.Vec4 // Left, right, bottom, top, near, far
+}
+
+// Indices into Frustum.Planes.
+const (
+	FrustumLeft = iota
+	FrustumRight
+	FrustumBottom
+	FrustumTop
+	FrustumNear
+	FrustumFar
+)
+
+// NewFrustum extracts the frustum planes from a combined projection * view matrix, for a projection with OpenGL's -1 to 1 depth range.
+func NewFrustum(viewProjection mgl32.Mat4) Frustum {
+	return newFrustum(viewProjection, false)
+}
+
+// NewReversedZFrustum extracts the frustum planes from a combined projection * view matrix, for a reversed-Z projection with a 1 to 0 depth range.
+func NewReversedZFrustum(viewProjection mgl32.Mat4) Frustum {
+	return newFrustum(viewProjection, true)
+}
+
+func newFrustum(m mgl32.Mat4, reversedZ bool) Frustum {
+	x, y, z, w := m.Row(0), m.Row(1), m.Row(2), m.Row(3)
+	var f Frustum
+	f.Planes[FrustumLeft] = w.Add(x)
+	f.Planes[FrustumRight] = w.Sub(x)
+	f.Planes[FrustumBottom] = w.Add(y)
+	f.Planes[FrustumTop] = w.Sub(y)
+	if reversedZ {
+		f.Planes[FrustumNear] = w.Sub(z)
+		f.Planes[FrustumFar] = z
+	} else {
+		f.Planes[FrustumNear] = w.Add(z)
+		f.Planes[FrustumFar] = w.Sub(z)
+	}
+	for i, plane := range f.Planes {
+		f.Planes[i] = normalisePlane(plane)
+	}
+	return f
+}
+
+// normalisePlane scales a plane so that its normal has unit length, making the plane equation return a true distance.
+func normalisePlane(plane mgl32.Vec4) mgl32.Vec4 {
+	length := plane.Vec3().Len()
+	if length == 0 {
+		//Degenerate plane, such as the far plane at infinity.  Accept everything.
+		return mgl32.Vec4{0, 0, 0, 1}
+	}
+	return plane.Mul(1 / length)
+}
+
+// planeDistance returns the signed distance from the plane to the point.  Positive values are inside the frustum.
+func planeDistance(plane mgl32.Vec4, p mgl32.Vec3) float32 {
+	return plane.X()*p.X() + plane.Y()*p.Y() + plane.Z()*p.Z() + plane.W()
+}
+
+// ContainsPoint reports whether the point is inside the frustum.
+func (f Frustum) ContainsPoint(p mgl32.Vec3) bool {
+	for _, plane := range f.Planes {
+		if planeDistance(plane, p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsSphere reports whether any part of the sphere might be inside the frustum.
+// Like most plane tests it is conservative: a sphere near a corner of the frustum may be reported as visible when it is not.
+func (f Frustum) IntersectsSphere(center mgl32.Vec3, radius float32) bool {
+	for _, plane := range f.Planes {
+		if planeDistance(plane, center) < -radius {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsAABB reports whether any part of the axis-aligned box between minCorner and maxCorner might be inside the frustum.
+// Like IntersectsSphere, it can report boxes near the corners of the frustum as visible.
+func (f Frustum) IntersectsAABB(minCorner, maxCorner mgl32.Vec3) bool {
+	for _, plane := range f.Planes {
+		//Test the corner of the box that lies furthest along the plane normal
+		corner := minCorner
+		if plane.X() >= 0 {
+			corner[0] = maxCorner.X()
+		}
+		if plane.Y() >= 0 {
+			corner[1] = maxCorner.Y()
+		}
+		if plane.Z() >= 0 {
+			corner[2] = maxCorner.Z()
+		}
+		if planeDistance(plane, corner) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ViewFrustum returns the frustum of the camera's current view and projection.
+func (c *Camera) ViewFrustum() Frustum {
+	return c.frustumFor(c.ViewProjectionMatrix())
+}
+
+// LeftEyeViewFrustum returns the frustum of the left eye, from LeftEyeFrustum and LeftEyeViewMatrix.
+func (c *Camera) LeftEyeViewFrustum() Frustum {
+	return NewFrustum(c.LeftEyeFrustum().Mul4(c.LeftEyeViewMatrix()))
+}
+
+// RightEyeViewFrustum returns the frustum of the right eye, from RightEyeFrustum and RightEyeViewMatrix.
+func (c *Camera) RightEyeViewFrustum() Frustum {
+	return NewFrustum(c.RightEyeFrustum().Mul4(c.RightEyeViewMatrix()))
+}
+
+// frustumFor extracts a frustum using the depth range of the camera's projection kind.
+func (c *Camera) frustumFor(viewProjection mgl32.Mat4) Frustum {
+	if c.Projection.reversedZ() {
+		return NewReversedZFrustum(viewProjection)
+	}
+	return NewFrustum(viewProjection)
+}
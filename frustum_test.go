@@ -0,0 +1,84 @@
+package sceneCamera
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestViewFrustumContainsPoint(t *testing.T) {
+	for _, kind := range []ProjectionKind{Perspective, Orthographic, ReversedZ, InfiniteFar, ReversedZInfiniteFar} {
+		t.Run(kind.String(), func(t *testing.T) {
+			camera := New(FPS)
+			camera.SetProjection(kind)
+			frustum := camera.ViewFrustum()
+
+			if !frustum.ContainsPoint(mgl32.Vec3{0, 0, 0}) {
+				t.Error("expected the target to be inside the frustum")
+			}
+			if frustum.ContainsPoint(mgl32.Vec3{0, 0, 6}) {
+				t.Error("expected a point behind the camera to be outside the frustum")
+			}
+			if frustum.ContainsPoint(mgl32.Vec3{0, 0, 4.95}) {
+				t.Error("expected a point in front of the near plane to be outside the frustum")
+			}
+			if frustum.ContainsPoint(mgl32.Vec3{100, 0, 0}) {
+				t.Error("expected a point far to the side to be outside the frustum")
+			}
+			beyondFar := frustum.ContainsPoint(mgl32.Vec3{0, 0, -30})
+			infinite := kind == InfiniteFar || kind == ReversedZInfiniteFar
+			if beyondFar != infinite {
+				t.Errorf("expected a point beyond the far plane to be inside only for infinite projections, got %v", beyondFar)
+			}
+		})
+	}
+}
+
+func TestFrustumIntersections(t *testing.T) {
+	camera := New(FPS)
+	frustum := camera.ViewFrustum()
+
+	if !frustum.IntersectsSphere(mgl32.Vec3{0, 0, 0}, 1) {
+		t.Error("expected a sphere at the target to intersect")
+	}
+	if !frustum.IntersectsSphere(mgl32.Vec3{0, 0, 7}, 2.5) {
+		t.Error("expected a sphere around the camera to intersect")
+	}
+	if frustum.IntersectsSphere(mgl32.Vec3{0, 0, 7}, 1) {
+		t.Error("expected a sphere behind the camera to be rejected")
+	}
+
+	if !frustum.IntersectsAABB(mgl32.Vec3{-1, -1, -1}, mgl32.Vec3{1, 1, 1}) {
+		t.Error("expected a box at the target to intersect")
+	}
+	if !frustum.IntersectsAABB(mgl32.Vec3{-100, -100, -1}, mgl32.Vec3{100, 100, 1}) {
+		t.Error("expected a box larger than the view to intersect")
+	}
+	if frustum.IntersectsAABB(mgl32.Vec3{50, -1, -1}, mgl32.Vec3{52, 1, 1}) {
+		t.Error("expected a box far to the side to be rejected")
+	}
+}
+
+func TestEyeViewFrustums(t *testing.T) {
+	camera := New(FPS)
+	camera.SetIPD(20)
+	camera.SetStereoModel(ParallelAxis)
+	left := camera.LeftEyeViewFrustum()
+	right := camera.RightEyeViewFrustum()
+
+	//Each eye is shifted 10 units sideways, so a point just left of the left eye is only visible to the left eye
+	point := mgl32.Vec3{-14, 0, -10}
+	if !left.ContainsPoint(point) {
+		t.Error("expected the point to be visible to the left eye")
+	}
+	if right.ContainsPoint(point) {
+		t.Error("expected the point to be hidden from the right eye")
+	}
+}
+
+func TestDegenerateFrustumPlane(t *testing.T) {
+	plane := normalisePlane(mgl32.Vec4{0, 0, 0, 2})
+	if plane != (mgl32.Vec4{0, 0, 0, 1}) {
+		t.Errorf("expected a degenerate plane to accept everything, got %v", plane)
+	}
+}
@@ -0,0 +1,52 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// GroundPointAt returns the point on the ground plane under a pixel, measured from the top left of the screen.
+// The ground plane passes through the origin, with the normal GroundPlaneNormal.
+// ok is false when the pixel is above the horizon, so the ray through it never reaches the ground.
+func (c *Camera) GroundPointAt(x, y float32) (point mgl32.Vec3, ok bool) {
+	origin, direction := c.ScreenRay(x, y)
+	return PlaneInterceptChecked(c.GroundPlaneNormal, origin, direction)
+}
+
+// BeginGroundDrag starts a "grab the ground" drag at a pixel.  The ground point under the pixel is remembered, and DragGround keeps it under the cursor.
+// It returns false, and does not start a drag, if the pixel is above the horizon.
+//
+// This is intended for RTS mode, but works in any mode.
+func (c *Camera) BeginGroundDrag(x, y float32) bool {
+	anchor, ok := c.GroundPointAt(x, y)
+	if !ok {
+		return false
+	}
+	c.dragAnchor = anchor
+	c.dragging = true
+	return true
+}
+
+// DragGround pans the camera parallel to the ground plane, so that the ground point grabbed by BeginGroundDrag is under the pixel.
+// The orientation of the camera does not change.  It returns false if no drag is in progress, or if the pixel is above the horizon.
+func (c *Camera) DragGround(x, y float32) bool {
+	if !c.dragging {
+		return false
+	}
+	current, ok := c.GroundPointAt(x, y)
+	if !ok {
+		return false
+	}
+	//Both points are on the ground, so the offset is parallel to it
+	offset := c.dragAnchor.Sub(current)
+	c.Position = c.Position.Add(offset)
+	c.Target = c.Target.Add(offset)
+	return true
+}
+
+// EndGroundDrag finishes a drag started by BeginGroundDrag.
+func (c *Camera) EndGroundDrag() {
+	c.dragging = false
+}
+
+// GroundDragging reports whether a drag started by BeginGroundDrag is in progress.
+func (c *Camera) GroundDragging() bool {
+	return c.dragging
+}
@@ -0,0 +1,69 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestGroundPointAt(t *testing.T) {
+	camera := New(RTS)
+	point, ok := camera.GroundPointAt(camera.Screenwidth/2, camera.Screenheight/2)
+	if !ok {
+		t.Fatal("expected the centre of the screen to hit the ground")
+	}
+	if !point.ApproxEqualThreshold(mgl32.Vec3{0, 0, 0}, 1e-3) {
+		t.Errorf("expected the centre of the screen to hit the target, got %v", point)
+	}
+
+	if _, ok := camera.GroundPointAt(camera.Screenwidth/2, 0); ok {
+		t.Error("expected the top of the screen to be above the horizon")
+	}
+}
+
+func TestGroundDrag(t *testing.T) {
+	for _, kind := range []ProjectionKind{Perspective, Orthographic} {
+		t.Run(kind.String(), func(t *testing.T) {
+			camera := New(RTS)
+			camera.SetProjection(kind)
+			orientation := camera.Orientation
+			if camera.DragGround(100, 100) {
+				t.Error("expected DragGround to fail before BeginGroundDrag")
+			}
+
+			start := mgl32.Vec2{960, 700}
+			anchor, _ := camera.GroundPointAt(start.X(), start.Y())
+			if !camera.BeginGroundDrag(start.X(), start.Y()) || !camera.GroundDragging() {
+				t.Fatal("expected the drag to start")
+			}
+			for _, pixel := range []mgl32.Vec2{{900, 720}, {700, 900}, {1200, 800}} {
+				if !camera.DragGround(pixel.X(), pixel.Y()) {
+					t.Fatalf("expected the drag to %v to succeed", pixel)
+				}
+				under, _ := camera.GroundPointAt(pixel.X(), pixel.Y())
+				if !under.ApproxEqualThreshold(anchor, 1e-3) {
+					t.Errorf("expected %v under the cursor at %v, got %v", anchor, pixel, under)
+				}
+			}
+			if !camera.Orientation.ApproxEqual(orientation) {
+				t.Error("expected the drag not to rotate the camera")
+			}
+			if math.Abs(float64(camera.Position.Z()-5)) > 1e-4 {
+				t.Errorf("expected the drag to keep the camera height, got %v", camera.Position)
+			}
+
+			camera.EndGroundDrag()
+			if camera.GroundDragging() || camera.DragGround(960, 540) {
+				t.Error("expected the drag to finish")
+			}
+		})
+	}
+}
+
+func TestBeginGroundDragAboveHorizon(t *testing.T) {
+	camera := New(RTS)
+	if camera.BeginGroundDrag(camera.Screenwidth/2, 0) {
+		t.Error("expected a drag above the horizon to be refused")
+	}
+}
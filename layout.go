@@ -0,0 +1,159 @@
+package sceneCamera
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// StereoLayout selects how the two eye images are arranged on the display.
+type StereoLayout int
+
+// Stereo layouts.  In each layout, Screenwidth by Screenheight is the size of the whole output, not of one eye's image.
+const (
+	// HalfSideBySide puts the left eye in the left half of the output and the right eye in the right half.  The display stretches each half back to full width, so each eye is rendered squeezed, with the aspect ratio of the whole output.
+	HalfSideBySide StereoLayout = iota
+	// FullSideBySide puts the eyes side by side like HalfSideBySide, but each eye keeps the aspect ratio of its own half.  This is what LeftEyeFrustum and RightEyeFrustum return.
+	FullSideBySide
+	// TopBottom puts the left eye in the top half of the output and the right eye in the bottom half.  The display stretches each half back to full height, so each eye is rendered squeezed.
+	TopBottom
+	// Anaglyph draws both eyes over the whole output, the left eye in red and the right eye in green and blue, for red-cyan glasses.
+	Anaglyph
+	// RowInterleaved draws both eyes over the whole output, keeping the left eye on even rows and the right eye on odd rows, for passive polarised displays.
+	RowInterleaved
+	// Multiview draws both eyes over the whole output, into layers 0 and 1 of a texture array, for single-pass multiview rendering.
+	Multiview
+)
+
+// String returns the name of the stereo layout.
+func (l StereoLayout) String() string {
+	switch l {
+	case HalfSideBySide:
+		return "HalfSideBySide"
+	case FullSideBySide:
+		return "FullSideBySide"
+	case TopBottom:
+		return "TopBottom"
+	case Anaglyph:
+		return "Anaglyph"
+	case RowInterleaved:
+		return "RowInterleaved"
+	case Multiview:
+		return "Multiview"
+	}
+	return fmt.Sprintf("StereoLayout(%d)", int(l))
+}
+
+// ErrUnknownStereoLayout is returned by StereoViews for a layout it does not know.
+var ErrUnknownStereoLayout = errors.New("unknown stereo layout")
+
+// Viewport is a rectangle of the output, in pixels, measured from the bottom left as gl.Viewport expects.
+type Viewport struct {
+	X, Y, Width, Height int
+}
+
+// ColorMask selects the colour channels an eye is drawn into, as gl.ColorMask expects.
+type ColorMask struct {
+	Red, Green, Blue, Alpha bool
+}
+
+// AllColors draws into every channel.
+var AllColors = ColorMask{true, true, true, true}
+
+// Rows selects the rows of the viewport that an eye is drawn into.
+type Rows int
+
+// Row selections.  Rows are counted from the bottom of the output, as gl_FragCoord counts them, so row 0 is even.
+const (
+	AllRows Rows = iota
+	EvenRows
+	OddRows
+)
+
+// String returns the name of the row selection.
+func (r Rows) String() string {
+	switch r {
+	case AllRows:
+		return "AllRows"
+	case EvenRows:
+		return "EvenRows"
+	case OddRows:
+		return "OddRows"
+	}
+	return fmt.Sprintf("Rows(%d)", int(r))
+}
+
+// StereoView is everything needed to draw one eye of a stereo layout.
+type StereoView struct {
+	Eye        Eye        //LeftEye or RightEye
+	View       mgl32.Mat4 //The eye's view matrix, from EyeViewMatrix
+	Projection mgl32.Mat4 //The eye's frustum, for the aspect ratio of its image in this layout
+	Viewport   Viewport   //Where the eye is drawn
+	ColorMask  ColorMask  //The colour channels the eye is drawn into
+	Rows       Rows       //The rows the eye is drawn into.  Anything other than AllRows needs a stencil or a shader to mask the other rows
+	Layer      int        //The texture array layer the eye is drawn into, for Multiview
+}
+
+// StereoViews returns the left and right eyes, in that order, arranged for the layout.
+// Screenwidth and Screenheight are the size of the whole output.  When both eyes share a viewport, as in Anaglyph and RowInterleaved, clear the depth buffer before drawing the second eye.
+// It returns a *ValidationError if the eye frustums cannot be built, or ErrUnknownStereoLayout.
+func (c *Camera) StereoViews(layout StereoLayout) ([2]StereoView, error) {
+	var views [2]StereoView
+	if layout < HalfSideBySide || layout > Multiview {
+		return views, fmt.Errorf("%w: %v", ErrUnknownStereoLayout, layout)
+	}
+	if err := c.validateEyeFrustum(); err != nil {
+		return views, err
+	}
+
+	width, height := int(c.Screenwidth+0.5), int(c.Screenheight+0.5)
+	aspect := c.Screenwidth / c.Screenheight
+	for i, eye := range [2]Eye{LeftEye, RightEye} {
+		views[i] = StereoView{
+			Eye:       eye,
+			View:      c.EyeViewMatrix(eye),
+			Viewport:  Viewport{0, 0, width, height},
+			ColorMask: AllColors,
+		}
+	}
+	left, right := &views[0], &views[1]
+
+	switch layout {
+	case HalfSideBySide, FullSideBySide:
+		left.Viewport.Width = width / 2
+		right.Viewport.X = width / 2
+		right.Viewport.Width = width - width/2
+		if layout == FullSideBySide {
+			aspect /= 2
+		}
+	case TopBottom:
+		//GL viewports start at the bottom, so the left eye is moved up
+		right.Viewport.Height = height / 2
+		left.Viewport.Y = height / 2
+		left.Viewport.Height = height - height/2
+	case Anaglyph:
+		left.ColorMask = ColorMask{Red: true, Alpha: true}
+		right.ColorMask = ColorMask{Green: true, Blue: true, Alpha: true}
+	case RowInterleaved:
+		left.Rows, right.Rows = EvenRows, OddRows
+	case Multiview:
+		left.Layer, right.Layer = 0, 1
+	}
+	left.Projection = c.eyeFrustumAspect(-1, aspect)
+	right.Projection = c.eyeFrustumAspect(1, aspect)
+	return views, nil
+}
+
+// MultiviewMatrices returns the left and right eye matrices as arrays, ready to upload as the uniform arrays of a single-pass multiview shader.
+// Element 0 is the left eye, in texture array layer 0.
+func (c *Camera) MultiviewMatrices() (views, projections [2]mgl32.Mat4, err error) {
+	stereo, err := c.StereoViews(Multiview)
+	if err != nil {
+		return views, projections, err
+	}
+	for i, view := range stereo {
+		views[i], projections[i] = view.View, view.Projection
+	}
+	return views, projections, nil
+}
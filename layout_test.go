@@ -0,0 +1,138 @@
+package sceneCamera
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStereoLayoutStrings(t *testing.T) {
+	testCases := []struct {
+		value    interface{ String() string }
+		expected string
+	}{
+		{HalfSideBySide, "HalfSideBySide"},
+		{FullSideBySide, "FullSideBySide"},
+		{TopBottom, "TopBottom"},
+		{Anaglyph, "Anaglyph"},
+		{RowInterleaved, "RowInterleaved"},
+		{Multiview, "Multiview"},
+		{StereoLayout(-1), "StereoLayout(-1)"},
+		{OddRows, "OddRows"},
+		{Rows(5), "Rows(5)"},
+	}
+	for _, testCase := range testCases {
+		if actual := testCase.value.String(); actual != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, actual)
+		}
+	}
+}
+
+func TestStereoViews(t *testing.T) {
+	red := ColorMask{Red: true, Alpha: true}
+	cyan := ColorMask{Green: true, Blue: true, Alpha: true}
+	testCases := []struct {
+		layout      StereoLayout
+		left, right Viewport
+		aspect      float32
+		leftMask    ColorMask
+		rightMask   ColorMask
+		leftRows    Rows
+		rightRows   Rows
+		leftLayer   int
+		rightLayer  int
+	}{
+		{HalfSideBySide, Viewport{0, 0, 960, 1080}, Viewport{960, 0, 960, 1080}, 1920.0 / 1080, AllColors, AllColors, AllRows, AllRows, 0, 0},
+		{FullSideBySide, Viewport{0, 0, 960, 1080}, Viewport{960, 0, 960, 1080}, 960.0 / 1080, AllColors, AllColors, AllRows, AllRows, 0, 0},
+		{TopBottom, Viewport{0, 540, 1920, 540}, Viewport{0, 0, 1920, 540}, 1920.0 / 1080, AllColors, AllColors, AllRows, AllRows, 0, 0},
+		{Anaglyph, Viewport{0, 0, 1920, 1080}, Viewport{0, 0, 1920, 1080}, 1920.0 / 1080, red, cyan, AllRows, AllRows, 0, 0},
+		{RowInterleaved, Viewport{0, 0, 1920, 1080}, Viewport{0, 0, 1920, 1080}, 1920.0 / 1080, AllColors, AllColors, EvenRows, OddRows, 0, 0},
+		{Multiview, Viewport{0, 0, 1920, 1080}, Viewport{0, 0, 1920, 1080}, 1920.0 / 1080, AllColors, AllColors, AllRows, AllRows, 0, 1},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.layout.String(), func(t *testing.T) {
+			camera := New(FPS)
+			views, err := camera.StereoViews(tt.layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			left, right := views[0], views[1]
+			if left.Eye != LeftEye || right.Eye != RightEye {
+				t.Errorf("expected the left eye first, got %v and %v", left.Eye, right.Eye)
+			}
+			if left.Viewport != tt.left || right.Viewport != tt.right {
+				t.Errorf("expected viewports %v and %v, got %v and %v", tt.left, tt.right, left.Viewport, right.Viewport)
+			}
+			if left.ColorMask != tt.leftMask || right.ColorMask != tt.rightMask {
+				t.Errorf("expected colour masks %v and %v, got %v and %v", tt.leftMask, tt.rightMask, left.ColorMask, right.ColorMask)
+			}
+			if left.Rows != tt.leftRows || right.Rows != tt.rightRows {
+				t.Errorf("expected rows %v and %v, got %v and %v", tt.leftRows, tt.rightRows, left.Rows, right.Rows)
+			}
+			if left.Layer != tt.leftLayer || right.Layer != tt.rightLayer {
+				t.Errorf("expected layers %d and %d, got %d and %d", tt.leftLayer, tt.rightLayer, left.Layer, right.Layer)
+			}
+			assertMat4(t, left.View, camera.LeftEyeViewMatrix())
+			assertMat4(t, right.View, camera.RightEyeViewMatrix())
+			for _, view := range views {
+				//The frustum's horizontal scale is the vertical scale divided by the aspect ratio
+				if aspect := view.Projection[5] / view.Projection[0]; math.Abs(float64(aspect-tt.aspect)) > 1e-4 {
+					t.Errorf("expected the %v eye aspect ratio %v, got %v", view.Eye, tt.aspect, aspect)
+				}
+			}
+		})
+	}
+}
+
+func TestFullSideBySideMatchesEyeFrustums(t *testing.T) {
+	camera := New(Museum)
+	views, err := camera.StereoViews(FullSideBySide)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMat4(t, views[0].Projection, camera.LeftEyeFrustum())
+	assertMat4(t, views[1].Projection, camera.RightEyeFrustum())
+}
+
+func TestStereoViewsOddSize(t *testing.T) {
+	camera := New(FPS)
+	camera.Screenwidth, camera.Screenheight = 1921, 1081
+	for _, layout := range []StereoLayout{HalfSideBySide, TopBottom} {
+		views, err := camera.StereoViews(layout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//The halves cover the whole output without overlapping
+		left, right := views[0].Viewport, views[1].Viewport
+		if left.Width*left.Height+right.Width*right.Height != 1921*1081 {
+			t.Errorf("%v: expected %v and %v to cover the output", layout, left, right)
+		}
+	}
+}
+
+func TestStereoViewsErrors(t *testing.T) {
+	camera := New(FPS)
+	if _, err := camera.StereoViews(StereoLayout(42)); !errors.Is(err, ErrUnknownStereoLayout) {
+		t.Errorf("expected ErrUnknownStereoLayout, got %v", err)
+	}
+	camera.Screenwidth = 0
+	if _, err := camera.StereoViews(Anaglyph); !errors.Is(err, ErrInvalidCamera) {
+		t.Errorf("expected ErrInvalidCamera, got %v", err)
+	}
+	if _, _, err := camera.MultiviewMatrices(); !errors.Is(err, ErrInvalidCamera) {
+		t.Errorf("expected ErrInvalidCamera, got %v", err)
+	}
+}
+
+func TestMultiviewMatrices(t *testing.T) {
+	camera := New(FPS)
+	views, projections, err := camera.MultiviewMatrices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stereo, _ := camera.StereoViews(Multiview)
+	for i := range stereo {
+		assertMat4(t, views[i], stereo[i].View)
+		assertMat4(t, projections[i], stereo[i].Projection)
+	}
+}
@@ -0,0 +1,89 @@
+package sceneCamera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// LightField describes the views of an autostereoscopic light-field display, such as a Looking Glass, and the quilt texture they are packed into.
+type LightField struct {
+	Views       int     //How many views, from left to right
+	Viewcone    float32 //The angle between the leftmost and rightmost views, seen from the middle of the focal plane, in radians
+	Columns     int     //How many tiles across the quilt
+	Rows        int     //How many tiles up the quilt
+	QuiltWidth  int     //The width of the quilt texture, in pixels
+	QuiltHeight int     //The height of the quilt texture, in pixels
+}
+
+// LightFieldView is everything needed to draw one view of a light field into its quilt tile.
+type LightFieldView struct {
+	Offset     float32    //How far the view is moved along the camera's right vector, in world space
+	View       mgl32.Mat4 //The view's view matrix, facing the same way as the camera
+	Projection mgl32.Mat4 //The view's frustum, sheared to converge with the others at FocalLength
+	Viewport   Viewport   //The view's tile in the quilt
+}
+
+// validate checks that the views fit in the quilt.
+func (l LightField) validate(v *validation) {
+	if l.Views < 1 {
+		v.add("Views", "is less than 1")
+	}
+	if !isFinite(l.Viewcone) || l.Viewcone < 0 || l.Viewcone >= math.Pi {
+		v.add("Viewcone", "is not between 0 and pi")
+	}
+	if l.Columns < 1 {
+		v.add("Columns", "is less than 1")
+	}
+	if l.Rows < 1 {
+		v.add("Rows", "is less than 1")
+	}
+	if l.Columns < 1 || l.Rows < 1 {
+		return
+	}
+	if l.Views > l.Columns*l.Rows {
+		v.add("Views", "is more than the %d tiles in the quilt", l.Columns*l.Rows)
+	}
+	if l.QuiltWidth < l.Columns {
+		v.add("QuiltWidth", "is less than one pixel per column")
+	}
+	if l.QuiltHeight < l.Rows {
+		v.add("QuiltHeight", "is less than one pixel per row")
+	}
+}
+
+// LightFieldViews returns the views of a light field, from left to right.
+// The views are spread along the camera's right vector, turned by up to half the viewcone either side of the middle of the focal plane, and each frustum is sheared like an OffAxis eye, so points at FocalLength have no disparity.
+// With two views and a viewcone covering the IPD, the views are the OffAxis eyes of StereoViews with HalfSideBySide, which draws each eye at the aspect ratio of the whole screen.
+//
+// Screenwidth and Screenheight are the size of the display, not of the quilt.  View 0 is in the bottom left tile of the quilt, and the views fill each row left to right, working upwards.
+// It returns a *ValidationError if the light field does not fit in its quilt, or the fields that the frustums use are invalid.
+func (c *Camera) LightFieldViews(field LightField) ([]LightFieldView, error) {
+	var v validation
+	field.validate(&v)
+	v.positive("FocalLength", c.FocalLength)
+	c.validateOffsetFrustum(&v)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	right := c.RightWardsVector()
+	aspect := c.Screenwidth / c.Screenheight
+	tileWidth, tileHeight := field.QuiltWidth/field.Columns, field.QuiltHeight/field.Rows
+	views := make([]LightFieldView, field.Views)
+	for i := range views {
+		//The angle from the middle of the focal plane to this view
+		var angle float64
+		if field.Views > 1 {
+			angle = (float64(i)/float64(field.Views-1) - 0.5) * float64(field.Viewcone)
+		}
+		offset := c.FocalLength * float32(math.Tan(angle))
+		views[i] = LightFieldView{
+			Offset:     offset,
+			View:       c.offsetViewMatrix(right, offset),
+			Projection: c.offsetFrustum(offset, aspect),
+			Viewport:   Viewport{i % field.Columns * tileWidth, i / field.Columns * tileHeight, tileWidth, tileHeight},
+		}
+	}
+	return views, nil
+}
@@ -0,0 +1,141 @@
+package sceneCamera
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// testLightField is a 45 view, 40 degree light field in a 5 by 9 quilt.
+var testLightField = LightField{Views: 45, Viewcone: 40 * math.Pi / 180, Columns: 5, Rows: 9, QuiltWidth: 4096, QuiltHeight: 4096}
+
+func TestLightFieldViews(t *testing.T) {
+	camera := New(FPS)
+	camera.Screenwidth, camera.Screenheight = 1536, 2048
+	views, err := camera.LightFieldViews(testLightField)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 45 {
+		t.Fatalf("expected 45 views, got %d", len(views))
+	}
+
+	//The views are spread evenly in angle, symmetrically about the camera
+	edge := camera.FocalLength * float32(math.Tan(20*math.Pi/180))
+	if math.Abs(float64(views[0].Offset+edge)) > 1e-5 || math.Abs(float64(views[44].Offset-edge)) > 1e-5 {
+		t.Errorf("expected offsets of -%v and %v, got %v and %v", edge, edge, views[0].Offset, views[44].Offset)
+	}
+	for i := 1; i < len(views); i++ {
+		if views[i].Offset <= views[i-1].Offset {
+			t.Errorf("expected the views from left to right, got %v after %v", views[i].Offset, views[i-1].Offset)
+		}
+	}
+
+	//The middle view is the mono view
+	assertMat4(t, views[22].View, camera.ViewMatrix())
+	assertMat4(t, views[22].Projection, camera.ProjectionMatrix())
+
+	//View 0 is bottom left, and the views fill each row before moving up
+	for i, expected := range map[int]Viewport{0: {0, 0, 819, 455}, 4: {3276, 0, 819, 455}, 5: {0, 455, 819, 455}, 44: {3276, 3640, 819, 455}} {
+		if views[i].Viewport != expected {
+			t.Errorf("expected view %d in %v, got %v", i, expected, views[i].Viewport)
+		}
+	}
+}
+
+func TestLightFieldZeroDisparityAtFocalPlane(t *testing.T) {
+	camera := New(FPS)
+	camera.Position = mgl32.Vec3{4, 3, 6}
+	camera.LookAt(-1, 1, 0)
+	camera.SetFocalLength(camera.Target.Sub(camera.Position).Len())
+	views, err := camera.LightFieldViews(testLightField)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	right, up, forward := camera.RightWardsVector(), camera.UpwardsVector(), camera.ForwardsVector()
+	onPlane := camera.Target.Add(right.Mul(0.7)).Add(up.Mul(-0.4))
+	behind := onPlane.Add(forward.Mul(3))
+	first := mgl32.TransformCoordinate(onPlane, views[0].Projection.Mul4(views[0].View))
+	previousBehind := float32(math.Inf(-1))
+	for i, view := range views {
+		transform := view.Projection.Mul4(view.View)
+		if ndc := mgl32.TransformCoordinate(onPlane, transform); ndc.Vec2().Sub(first.Vec2()).Len() > 1e-4 {
+			t.Errorf("expected view %d to draw the focal plane point at %v, got %v", i, first, ndc)
+		}
+		//Points behind the focal plane move steadily across the views
+		ndc := mgl32.TransformCoordinate(behind, transform)
+		if ndc.X() <= previousBehind {
+			t.Errorf("expected view %d to draw the point behind the focal plane further right, got %v after %v", i, ndc.X(), previousBehind)
+		}
+		previousBehind = ndc.X()
+	}
+}
+
+func TestLightFieldGeneralisesStereo(t *testing.T) {
+	camera := New(Museum)
+	camera.SetIPD(0.5)
+	//Two views, with the viewcone spanning the eyes
+	viewcone := float32(2 * math.Atan(float64(camera.IPD/2/camera.FocalLength)))
+	views, err := camera.LightFieldViews(LightField{Views: 2, Viewcone: viewcone, Columns: 2, Rows: 1, QuiltWidth: 1920, QuiltHeight: 1080})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stereo, err := camera.StereoViews(HalfSideBySide)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range stereo {
+		assertMat4(t, views[i].View, stereo[i].View)
+		assertMat4(t, views[i].Projection, stereo[i].Projection)
+		if views[i].Viewport != stereo[i].Viewport {
+			t.Errorf("expected tile %v, got %v", stereo[i].Viewport, views[i].Viewport)
+		}
+	}
+}
+
+func TestLightFieldSingleView(t *testing.T) {
+	camera := New(FPS)
+	views, err := camera.LightFieldViews(LightField{Views: 1, Viewcone: 0.5, Columns: 1, Rows: 1, QuiltWidth: 1920, QuiltHeight: 1080})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMat4(t, views[0].View, camera.ViewMatrix())
+	assertMat4(t, views[0].Projection, camera.ProjectionMatrix())
+}
+
+func TestLightFieldErrorMessages(t *testing.T) {
+	_, err := New(FPS).LightFieldViews(LightField{Views: 1, QuiltWidth: 1, QuiltHeight: 1})
+	if err == nil || err.Error() != "invalid camera: Columns is less than 1; Rows is less than 1" {
+		t.Errorf("expected an error for each field, got %v", err)
+	}
+}
+
+func TestLightFieldErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		field    LightField
+		change   func(c *Camera)
+		expected []string
+	}{
+		{"no views", LightField{Columns: 1, Rows: 1, QuiltWidth: 1, QuiltHeight: 1}, func(c *Camera) {}, []string{"Views"}},
+		{"viewcone", LightField{Views: 1, Viewcone: 4, Columns: 1, Rows: 1, QuiltWidth: 1, QuiltHeight: 1}, func(c *Camera) {}, []string{"Viewcone"}},
+		{"too many views", LightField{Views: 45, Columns: 5, Rows: 8, QuiltWidth: 4096, QuiltHeight: 4096}, func(c *Camera) {}, []string{"Views"}},
+		{"no columns", LightField{Views: 1, Columns: 0, Rows: 1, QuiltWidth: 1, QuiltHeight: 1}, func(c *Camera) {}, []string{"Columns"}},
+		{"no tiles", LightField{Views: 1, Columns: 0, Rows: 0, QuiltWidth: 1, QuiltHeight: 1}, func(c *Camera) {}, []string{"Columns", "Rows"}},
+		{"small quilt", LightField{Views: 4, Columns: 2, Rows: 2, QuiltWidth: 1, QuiltHeight: 1}, func(c *Camera) {}, []string{"QuiltWidth", "QuiltHeight"}},
+		{"camera", testLightField, func(c *Camera) { c.FocalLength, c.Screenheight = 0, 0 }, []string{"FocalLength", "Screenheight"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			camera := New(FPS)
+			testCase.change(camera)
+			_, err := camera.LightFieldViews(testCase.field)
+			if fields := invalidFields(t, err); !reflect.DeepEqual(fields, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package sceneCamera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// MinTargetDistance is the closest the target can be to the camera.  Any closer, and the direction to it is lost to rounding.
+const MinTargetDistance = 1e-3
+
+// maxPitch is the furthest above or below the horizon that pitching and orbiting go.  Beyond it, the view direction is so close to Up that it no longer says which way is up.
+const maxPitch = math.Pi/2 - 1e-3
+
+// parallelEpsilon is the length of the cross product of two unit vectors below which they are treated as parallel.
+const parallelEpsilon = 1e-6
+
+// elevation returns the angle of v above the plane at right angles to up, in radians.
+func elevation(v, up mgl32.Vec3) float32 {
+	if v.Len() == 0 || up.Len() == 0 {
+		return 0
+	}
+	return float32(math.Asin(float64(mgl32.Clamp(v.Normalize().Dot(up.Normalize()), -1, 1))))
+}
+
+// clampPitch limits a change in elevation, so that it does not take a direction at elevation current past maxPitch above or below the horizon.
+// A direction that is already past the limit may turn back towards the horizon, but no further away.
+func clampPitch(current, change float32) float32 {
+	if change > 0 {
+		return max(min(change, maxPitch-current), 0)
+	}
+	return min(max(change, -maxPitch-current), 0)
+}
+
+// groundTarget returns the ground point under the centre of the screen, for RTS mode.
+// If the camera is looking at or above the horizon, it is the ground ahead instead, as far away as the camera is high.
+func (c *Camera) groundTarget(forward, up mgl32.Vec3) mgl32.Vec3 {
+	normal := c.GroundPlaneNormal.Normalize()
+	if target, ok := PlaneInterceptChecked(normal, c.Position, forward); ok {
+		return target
+	}
+	heading := ProjectPlane(normal, forward)
+	if heading.Len() < parallelEpsilon {
+		//Looking straight up, so the top of the screen is the way ahead
+		heading = ProjectPlane(normal, up)
+	}
+	height := c.Position.Dot(normal)
+	below := c.Position.Sub(normal.Mul(height))
+	return below.Add(heading.Normalize().Mul(max(abs(height), 1)))
+}
+
+// orbitRTSPitch turns the camera about a level axis through the target, stopping short of looking straight down or up.
+func (c *Camera) orbitRTSPitch(angle float32, axis, offset mgl32.Vec3) {
+	//The axis is level and at right angles to the offset, so the offset's elevation changes by exactly the angle, one way or the other
+	sign := float32(1)
+	if axis.Cross(offset).Dot(c.GroundPlaneNormal) < 0 {
+		sign = -1
+	}
+	angle = sign * clampPitch(elevation(offset, c.GroundPlaneNormal), sign*angle)
+	c.Position = c.Target.Add(mgl32.QuatRotate(angle, axis).Rotate(offset))
+	c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
+}
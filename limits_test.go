@@ -0,0 +1,193 @@
+package sceneCamera
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestLookAtAlongUp(t *testing.T) {
+	camera := New(FPS)
+	camera.SetUp(0, 0, 1)
+	camera.LookAt(0, 0, 10)
+	assertFiniteQuat(t, camera.Orientation)
+	assertVec3(t, camera.ForwardsVector(), mgl32.Vec3{0, 0, 1})
+	forward, _, _ := camera.cameraAxes()
+	assertVec3(t, forward, mgl32.Vec3{0, 0, 1})
+	assertFiniteMat4(t, camera.ViewMatrix())
+	if right := camera.RightWardsVector(); math.Abs(float64(right.Len()-1)) > 1e-5 || math.Abs(float64(right.Dot(forward))) > 1e-5 {
+		t.Errorf("expected a unit right vector square to forward, got %v", right)
+	}
+
+	//A zero Up falls back in the same way
+	camera.SetUp(0, 0, 0)
+	camera.LookAt(10, 0, 0)
+	assertFiniteQuat(t, camera.Orientation)
+}
+
+func TestLookAtOwnPosition(t *testing.T) {
+	camera := New(FPS)
+	forward := camera.ForwardsVector()
+	camera.LookAt(camera.Position.Elem())
+	assertFiniteQuat(t, camera.Orientation)
+	assertVec3(t, camera.ForwardsVector(), forward)
+	assertFloat(t, camera.Target.Sub(camera.Position).Len(), MinTargetDistance)
+
+	//Setting the position onto the target directly falls back to the orientation
+	camera.SetPosition(camera.Target.Elem())
+	assertVec3(t, camera.ForwardsVector(), forward)
+	camera.Move(Forward, 1)
+	assertFiniteQuat(t, camera.Orientation)
+	assertFiniteMat4(t, camera.ViewMatrix())
+}
+
+func TestMuseumZoomStopsAtTarget(t *testing.T) {
+	camera := New(Museum)
+	forward := camera.ForwardsVector()
+	camera.Move(Forward, 100)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 0, camera.MinZoomDistance})
+	assertVec3(t, camera.ForwardsVector(), forward)
+	assertFiniteQuat(t, camera.Orientation)
+
+	//Zooming back out still works
+	camera.Move(Backward, 2)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 0, camera.MinZoomDistance + 2})
+}
+
+func TestRTSZoomStopsAtGround(t *testing.T) {
+	camera := New(RTS)
+	camera.Move(Up, 100)
+	assertFiniteQuat(t, camera.Orientation)
+	assertFloat(t, camera.Position.Len(), camera.MinZoomDistance)
+	if camera.Position.Z() <= 0 {
+		t.Errorf("expected the camera to stay above the ground, got %v", camera.Position)
+	}
+}
+
+func TestFPSPitchStopsShortOfThePoles(t *testing.T) {
+	camera := walkingPose.camera()
+	for i := 0; i < 100; i++ {
+		camera.Move(PitchUp, 0.1)
+	}
+	assertFiniteQuat(t, camera.Orientation)
+	if angle := elevation(camera.TargetVector(), camera.Up); angle > maxPitch+1e-4 || angle < maxPitch-1e-2 {
+		t.Errorf("expected the pitch to stop at %v, got %v", maxPitch, angle)
+	}
+	//Still facing the same way
+	if camera.ForwardsVector().X() <= 0 {
+		t.Errorf("expected the camera not to flip over, got forward %v", camera.ForwardsVector())
+	}
+
+	for i := 0; i < 100; i++ {
+		camera.Move(PitchDown, 0.1)
+	}
+	if angle := elevation(camera.TargetVector(), camera.Up); angle < -maxPitch-1e-4 {
+		t.Errorf("expected the pitch to stop at %v, got %v", -maxPitch, angle)
+	}
+	if camera.ForwardsVector().X() <= 0 {
+		t.Errorf("expected the camera not to flip over, got forward %v", camera.ForwardsVector())
+	}
+}
+
+func TestRTSPitchStopsShortOfThePoles(t *testing.T) {
+	for _, direction := range []Direction{PitchUp, PitchDown} {
+		t.Run(direction.String(), func(t *testing.T) {
+			camera := New(RTS)
+			for i := 0; i < 100; i++ {
+				camera.Move(direction, 0.1)
+				assertFiniteQuat(t, camera.Orientation)
+			}
+			offset := camera.Position.Sub(camera.Target)
+			if angle := abs(elevation(offset, camera.GroundPlaneNormal)); angle > maxPitch+1e-4 {
+				t.Errorf("expected the orbit to stop at %v, got %v", maxPitch, angle)
+			}
+			assertFiniteMat4(t, camera.ViewMatrix())
+		})
+	}
+}
+
+func TestClampPitch(t *testing.T) {
+	testCases := []struct {
+		name            string
+		current, change float32
+		expected        float32
+	}{
+		{"within", 0, 0.5, 0.5},
+		{"up to limit", maxPitch - 0.1, 0.5, 0.1},
+		{"down to limit", -maxPitch + 0.1, -0.5, -0.1},
+		{"past limit, further", maxPitch + 0.1, 0.5, 0},
+		{"past limit, back", maxPitch + 0.1, -0.5, -0.5},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assertFloat(t, clampPitch(testCase.current, testCase.change), testCase.expected)
+		})
+	}
+}
+
+func TestPlaneInterceptChecked(t *testing.T) {
+	up := mgl32.Vec3{0, 0, 1}
+	testCases := []struct {
+		name              string
+		normal, origin    mgl32.Vec3
+		direction         mgl32.Vec3
+		expected          mgl32.Vec3
+		expectedIntercept bool
+	}{
+		{"ahead", up, mgl32.Vec3{1, 2, 5}, mgl32.Vec3{0, 0, -1}, mgl32.Vec3{1, 2, 0}, true},
+		{"slanted", up, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{1, 0, -1}, mgl32.Vec3{5, 0, 0}, true},
+		{"behind", up, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 1}, mgl32.Vec3{}, false},
+		{"parallel", up, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{}, false},
+		{"zero normal", mgl32.Vec3{}, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, -1}, mgl32.Vec3{}, false},
+		{"zero direction", up, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{}, mgl32.Vec3{}, false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			point, ok := PlaneInterceptChecked(testCase.normal, testCase.origin, testCase.direction)
+			if ok != testCase.expectedIntercept {
+				t.Fatalf("expected ok %v, got %v", testCase.expectedIntercept, ok)
+			}
+			assertVec3(t, point, testCase.expected)
+
+			//The same plane, moved off the origin
+			shift := mgl32.Vec3{0, 0, 2}
+			point, ok = PlaneIntercept2Checked(shift, testCase.normal, testCase.origin.Add(shift), testCase.direction)
+			if ok != testCase.expectedIntercept {
+				t.Fatalf("expected ok %v from PlaneIntercept2Checked, got %v", testCase.expectedIntercept, ok)
+			}
+			if ok {
+				assertVec3(t, point, testCase.expected.Add(shift))
+			}
+		})
+	}
+}
+
+func TestLongSessionDoesNotDrift(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, mode := range []Mode{Museum, FPS, RTS} {
+		t.Run(mode.String(), func(t *testing.T) {
+			camera := New(mode)
+			distance := camera.TargetVector().Len()
+			for i := 0; i < 20000; i++ {
+				direction := Direction(random.Intn(int(YawRight) + 1))
+				amount := (random.Float32() - 0.5) * 0.2
+				if direction == Forward || direction == Backward || direction == Up || direction == Down {
+					//Keep the zoom from wandering off, so that the check is on rounding and not on the walk
+					amount = 0.01
+				}
+				camera.Move(direction, amount)
+			}
+			assertFiniteQuat(t, camera.Orientation)
+			assertFiniteMat4(t, camera.ViewMatrix())
+			if mode == FPS {
+				assertFloat(t, camera.TargetVector().Len(), distance)
+			}
+			forward, _, _ := camera.cameraAxes()
+			if forward.Dot(camera.ForwardsVector()) < 1-1e-4 {
+				t.Errorf("expected the orientation to match the target, got %v and %v", forward, camera.ForwardsVector())
+			}
+		})
+	}
+}
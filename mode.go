@@ -0,0 +1,97 @@
+package sceneCamera
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Mode selects how the camera responds to movement commands.  Each mode is implemented by a Controller.
+type Mode int
+
+// Built-in camera modes.  The values match the integers accepted by earlier versions of New and SetMode.  Add more modes with RegisterMode.
+const (
+	Museum Mode = 1 // Orbit around the target, and zoom in or out
+	FPS    Mode = 2 // Translate, pitch and yaw like a first-person camera
+	RTS    Mode = 3 // Pan over the ground plane, and orbit a point on it
+	Flight Mode = 4 // Fly freely in the camera's own axes, with pitch, yaw and roll
+	Follow Mode = 5 // Follow behind a moving subject, with spring lag
+)
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	if entry, ok := lookupMode(m); ok {
+		return entry.name
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Direction is a movement command passed to Move and MoveChecked.
+type Direction int
+
+// Movement directions.  The values match the integers accepted by earlier versions of Move.
+const (
+	Forward Direction = iota
+	Backward
+	Left
+	Right
+	Up
+	Down
+	PitchUp
+	PitchDown
+	YawLeft
+	YawRight
+	RollLeft
+	RollRight
+)
+
+var directionNames = [...]string{
+	Forward:   "Forward",
+	Backward:  "Backward",
+	Left:      "Left",
+	Right:     "Right",
+	Up:        "Up",
+	Down:      "Down",
+	PitchUp:   "PitchUp",
+	PitchDown: "PitchDown",
+	YawLeft:   "YawLeft",
+	YawRight:  "YawRight",
+	RollLeft:  "RollLeft",
+	RollRight: "RollRight",
+}
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	if d >= 0 && int(d) < len(directionNames) {
+		return directionNames[d]
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// ErrUnknownMode is returned by MoveChecked when the camera is in a mode that is neither built in nor registered.
+var ErrUnknownMode = errors.New("unknown camera mode")
+
+// ErrUnsupportedDirection is returned by MoveChecked when the direction has no effect in the current mode.
+var ErrUnsupportedDirection = errors.New("direction not supported in camera mode")
+
+// Supports reports whether the mode responds to the direction.
+func (m Mode) Supports(direction Direction) bool {
+	entry, ok := lookupMode(m)
+	if !ok || direction < 0 || int(direction) >= len(entry.supported) {
+		return false
+	}
+	return entry.supported[direction]
+}
+
+// MoveChecked moves the camera like Move, but returns an error instead of ignoring a direction that the current mode does not support.
+// The camera is not changed when an error is returned.
+func (c *Camera) MoveChecked(direction Direction, amount float32) error {
+	controller := c.activeController()
+	if controller == nil {
+		return fmt.Errorf("%w: %v", ErrUnknownMode, c.Mode)
+	}
+	if !controller.Supports(direction) {
+		return fmt.Errorf("%w: %v in %v mode", ErrUnsupportedDirection, direction, c.Mode)
+	}
+	controller.Move(c, direction, amount)
+	return nil
+}
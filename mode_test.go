@@ -0,0 +1,79 @@
+package sceneCamera
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestModeAndDirectionStrings(t *testing.T) {
+	testCases := []struct {
+		value    interface{ String() string }
+		expected string
+	}{
+		{value: Museum, expected: "Museum"},
+		{value: FPS, expected: "FPS"},
+		{value: RTS, expected: "RTS"},
+		{value: Flight, expected: "Flight"},
+		{value: Follow, expected: "Follow"},
+		{value: Mode(42), expected: "Mode(42)"},
+		{value: Forward, expected: "Forward"},
+		{value: PitchDown, expected: "PitchDown"},
+		{value: RollRight, expected: "RollRight"},
+		{value: Direction(-1), expected: "Direction(-1)"},
+		{value: Direction(12), expected: "Direction(12)"},
+	}
+
+	for _, testCase := range testCases {
+		if actual := testCase.value.String(); actual != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, actual)
+		}
+	}
+}
+
+func TestMoveChecked(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mode      Mode
+		direction Direction
+		expected  error
+	}{
+		{name: "museum-zoom", mode: Museum, direction: Forward},
+		{name: "museum-orbit", mode: Museum, direction: Down},
+		{name: "museum-pitch", mode: Museum, direction: PitchUp, expected: ErrUnsupportedDirection},
+		{name: "fps-yaw", mode: FPS, direction: YawRight},
+		{name: "fps-roll", mode: FPS, direction: RollLeft, expected: ErrUnsupportedDirection},
+		{name: "rts-orbit", mode: RTS, direction: PitchDown},
+		{name: "flight-roll", mode: Flight, direction: RollRight},
+		{name: "follow-yaw", mode: Follow, direction: YawLeft, expected: ErrUnsupportedDirection},
+		{name: "rts-roll", mode: RTS, direction: RollRight, expected: ErrUnsupportedDirection},
+		{name: "unknown-direction", mode: FPS, direction: Direction(12), expected: ErrUnsupportedDirection},
+		{name: "unknown-mode", mode: Mode(0), direction: Forward, expected: ErrUnknownMode},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			camera := New(testCase.mode)
+			original := camera.Position
+			err := camera.MoveChecked(testCase.direction, 0.25)
+			if !errors.Is(err, testCase.expected) {
+				t.Fatalf("expected error %v, got %v", testCase.expected, err)
+			}
+			if err != nil {
+				assertVec3(t, camera.Position, original)
+			}
+		})
+	}
+}
+
+func TestMoveCheckedMatchesMove(t *testing.T) {
+	checked := New(FPS)
+	unchecked := New(FPS)
+	if err := checked.MoveChecked(Forward, 1); err != nil {
+		t.Fatal(err)
+	}
+	unchecked.Move(Forward, 1)
+	assertVec3(t, checked.Position, unchecked.Position)
+	assertVec3(t, checked.Position, mgl32.Vec3{0, 0, 4})
+}
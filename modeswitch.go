@@ -0,0 +1,82 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Each built-in controller's Enter converts the camera's state into the form its mode expects, keeping the view direction where it can, so that switching modes does not make the view jump.
+
+// Enter picks an orbit pivot: the ground under the centre of the screen if it is in view, or otherwise the point ahead at the current target distance.
+func (museumController) Enter(c *Camera) {
+	forward := c.viewDirection()
+	pivot, ok := PlaneInterceptChecked(c.GroundPlaneNormal, c.Position, forward)
+	if !ok || pivot.Sub(c.Position).Len() > c.Far || pivot.Sub(c.Position).Len() < c.Near {
+		distance := max(c.Target.Sub(c.Position).Len(), c.MinZoomDistance, c.Near)
+		pivot = c.Position.Add(forward.Mul(distance))
+	}
+	c.LookAt(pivot.X(), pivot.Y(), pivot.Z())
+}
+
+func (museumController) Exit(c *Camera) {}
+
+// Enter points the look target along the current view direction.
+func (fpsController) Enter(c *Camera) {
+	forward := c.viewDirection()
+	distance := c.Target.Sub(c.Position).Len()
+	if distance == 0 {
+		distance = 1
+	}
+	target := c.Position.Add(forward.Mul(distance))
+	c.LookAt(target.X(), target.Y(), target.Z())
+}
+
+func (fpsController) Exit(c *Camera) {}
+
+// Enter sets Up to the ground normal, and moves the target to the ground under the centre of the screen.
+// If the camera is looking at or above the horizon, it tilts down to look at the ground ahead, as far away as the camera is high.
+func (rtsController) Enter(c *Camera) {
+	forward := c.viewDirection()
+	_, _, up := c.cameraAxes()
+	c.Up = c.GroundPlaneNormal
+	target := c.groundTarget(forward, up)
+	c.LookAt(target.X(), target.Y(), target.Z())
+}
+
+func (rtsController) Exit(c *Camera) {}
+
+// Exit hands back a level up vector, since Flight mode turns Up with the camera.
+func (flightController) Exit(c *Camera) {
+	forward, _, _ := c.cameraAxes()
+	if forward.Cross(c.GroundPlaneNormal).Len() > 1e-6 {
+		c.Up = c.GroundPlaneNormal
+	}
+}
+
+// viewDirection returns the direction the camera is looking, from Orientation.
+// When the direction to Target agrees with it, that is used instead, since it is not affected by rounding in the quaternion.
+func (c *Camera) viewDirection() mgl32.Vec3 {
+	forward, _, _ := c.cameraAxes()
+	if toTarget := c.Target.Sub(c.Position); toTarget.Len() > 0 && toTarget.Normalize().Dot(forward) > 1-1e-5 {
+		return toTarget.Normalize()
+	}
+	return forward
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// BlendToMode switches mode like SetMode, then moves the camera smoothly from the old view to the new mode's view over duration seconds, as Update is called.
+// The blend is a FlyTo, so Flying reports it, and CancelFlyTo stops it.  A nil easing uses EaseInOut.
+func (c *Camera) BlendToMode(mode Mode, duration float32, easing Easing) {
+	before := c.Pose()
+	beforeOrientation := c.Orientation
+	c.SetMode(mode)
+	after := c.Pose()
+
+	//Start from the old view, with the new mode's controller in charge
+	c.Position, c.Target, c.Up, c.FOV = before.Position, before.Target, before.Up, before.FOV
+	c.Orientation = beforeOrientation
+	c.FlyTo(Bookmark{Pose: after}, duration, easing)
+}
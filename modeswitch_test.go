@@ -0,0 +1,131 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestSwitchToRTSKeepsView(t *testing.T) {
+	camera := walkingPose.camera()
+	forward := camera.ForwardsVector()
+	camera.SetMode(RTS)
+	assertVec3(t, camera.ForwardsVector(), forward)
+	assertVec3(t, camera.Target, mgl32.Vec3{10, 0, 0})
+	assertVec3(t, camera.Up, camera.GroundPlaneNormal)
+
+	//Panning now keeps the target on the ground
+	camera.Move(Forward, 1)
+	assertFloat(t, camera.Target.Z(), 0)
+}
+
+func TestSwitchToRTSAboveHorizon(t *testing.T) {
+	camera := walkingPose.camera()
+	camera.LookAt(10, 0, 5)
+	camera.SetMode(RTS)
+	//The camera tilts down to the ground ahead, as far away as it is high
+	assertVec3(t, camera.Target, mgl32.Vec3{2, 0, 0})
+	assertVec3(t, camera.Up, camera.GroundPlaneNormal)
+	assertFiniteMat4(t, camera.ViewMatrix())
+}
+
+func TestSwitchToMuseumPicksPivot(t *testing.T) {
+	camera := walkingPose.camera()
+	camera.LookAt(1, 0, 1.9) //A short look target, not on the ground
+	camera.Far = 100
+	forward := camera.ForwardsVector()
+	camera.SetMode(Museum)
+	assertVec3(t, camera.ForwardsVector(), forward)
+	//The pivot is the ground under the centre of the screen
+	assertVec3(t, camera.Target, mgl32.Vec3{20, 0, 0})
+
+	//Looking above the horizon, the pivot stays at the target distance
+	camera = walkingPose.camera()
+	camera.LookAt(3, 0, 6)
+	camera.SetMode(Museum)
+	assertVec3(t, camera.Target, mgl32.Vec3{3, 0, 6})
+
+	//Orbiting keeps the pivot
+	camera.Move(Left, 0.5)
+	assertVec3(t, camera.Target, mgl32.Vec3{3, 0, 6})
+}
+
+func TestSwitchFromFlightLevelsUp(t *testing.T) {
+	camera := flyingPose.camera()
+	camera.Move(RollLeft, 0.7)
+	camera.Move(PitchUp, 0.2)
+	forward := camera.ForwardsVector()
+
+	camera.SetMode(FPS)
+	assertVec3(t, camera.ForwardsVector(), forward)
+	assertVec3(t, camera.Up, camera.GroundPlaneNormal)
+	if math.Abs(float64(camera.RightWardsVector().Dot(camera.GroundPlaneNormal))) > 1e-5 {
+		t.Errorf("expected the roll to be levelled, got right %v", camera.RightWardsVector())
+	}
+}
+
+func TestSwitchFromRTSToFPS(t *testing.T) {
+	camera := New(RTS)
+	forward := camera.ForwardsVector()
+	target := camera.Target
+	camera.SetMode(FPS)
+	assertVec3(t, camera.ForwardsVector(), forward)
+	assertVec3Near(t, camera.Target, target, 1e-4)
+}
+
+func TestRestoreDoesNotConvert(t *testing.T) {
+	camera := walkingPose.camera()
+	camera.LookAt(1, 0, 1.9)
+	saved := camera.Snapshot()
+	saved.Mode = Museum
+	camera.SetMode(RTS)
+	camera.Restore(saved)
+	if camera.Snapshot() != saved {
+		t.Errorf("expected %+v, got %+v", saved, camera.Snapshot())
+	}
+	if _, ok := camera.Controller().(museumController); !ok {
+		t.Errorf("expected the museum controller, got %T", camera.Controller())
+	}
+}
+
+func TestBlendToMode(t *testing.T) {
+	camera := walkingPose.camera()
+	camera.LookAt(10, 0, 5)
+	before := camera.Orientation
+	expected := walkingPose.camera()
+	expected.LookAt(10, 0, 5)
+	expected.SetMode(RTS)
+
+	camera.BlendToMode(RTS, 1, nil)
+	if camera.Mode != RTS || !camera.Flying() {
+		t.Fatalf("expected an RTS camera blending, got %v %v", camera.Mode, camera.Flying())
+	}
+	//Nothing moves until time passes
+	if !camera.Orientation.OrientationEqualThreshold(before, 1e-5) {
+		t.Errorf("expected the view not to jump, got %v from %v", camera.Orientation, before)
+	}
+
+	const steps = 50
+	previous := camera.Orientation
+	//One step extra, in case rounding leaves the blend a fraction short
+	for i := 0; i <= steps; i++ {
+		camera.Update(1.0 / steps)
+		if angle := quatAngle(previous, camera.Orientation); angle > 0.05 {
+			t.Fatalf("expected a smooth blend, turned %v radians in one step", angle)
+		}
+		previous = camera.Orientation
+	}
+	if camera.Flying() {
+		t.Errorf("expected the blend to be over")
+	}
+	assertVec3(t, camera.Position, expected.Position)
+	assertVec3(t, camera.Target, expected.Target)
+	assertVec3(t, camera.Up, expected.Up)
+}
+
+// quatAngle returns the angle between two orientations, in radians.
+func quatAngle(a, b mgl32.Quat) float32 {
+	dot := math.Abs(float64(a.Dot(b)))
+	return float32(2 * math.Acos(math.Min(dot, 1)))
+}
@@ -0,0 +1,124 @@
+package sceneCamera
+
+import "math"
+
+// Update moves the camera smoothly over dt seconds, towards the velocities set with SetDesiredVelocity.
+//
+// Each pair of opposite directions, such as Forward and Backward, is an axis with its own velocity.
+// While an axis has a desired velocity, its velocity changes towards it at Acceleration (TurnAcceleration for pitch, yaw and roll), and is limited to MaxSpeed (MaxTurnRate).
+// Once the desired velocity is zero, the camera coasts to a stop, with its velocity decaying exponentially at the rate Damping.
+//
+// Velocities are in the units that Move uses for the current mode, per second.  The movement itself is applied through Move, so it behaves the same way in every mode.
+//
+// Update then calls the current mode's Controller, which may move the camera further.  For example, Flight mode rolls towards level at AutoLevelRate, and Follow mode moves after its subject.
+// While a transition started by FlyTo is running, Update only moves the camera along it.
+func (c *Camera) Update(dt float32) {
+	if dt <= 0 {
+		return
+	}
+	if c.transition.active {
+		c.updateTransition(dt)
+		return
+	}
+	for axis := range c.velocity {
+		rotation := Direction(axis*2) >= PitchUp
+		acceleration, maxSpeed := c.Acceleration, c.MaxSpeed
+		if rotation {
+			acceleration, maxSpeed = c.TurnAcceleration, c.MaxTurnRate
+		}
+
+		previous := c.velocity[axis]
+		velocity := previous
+		desired := c.desiredVelocity[axis]
+		switch {
+		case desired == 0 && c.Damping <= 0, desired != 0 && acceleration <= 0:
+			//No inertia, so the new velocity applies for the whole step
+			previous, velocity = desired, desired
+		case desired == 0:
+			velocity = dampVelocity(velocity, c.Damping, dt)
+		default:
+			velocity = accelerateVelocity(velocity, desired, acceleration, dt)
+		}
+		if maxSpeed > 0 {
+			previous = min(max(previous, -maxSpeed), maxSpeed)
+			velocity = min(max(velocity, -maxSpeed), maxSpeed)
+		}
+		c.velocity[axis] = velocity
+
+		//Average the velocity over the step, so the distance covered does not depend on the frame rate.
+		//Positive velocities move in the first direction of the pair, negative ones in the second
+		amount := (previous + velocity) / 2 * dt
+		switch {
+		case amount > 0:
+			c.Move(Direction(axis*2), amount)
+		case amount < 0:
+			c.Move(Direction(axis*2+1), -amount)
+		}
+	}
+	if controller := c.activeController(); controller != nil {
+		controller.Update(c, dt)
+	}
+}
+
+// accelerateVelocity moves a velocity towards the desired velocity, changing it by at most acceleration*dt.
+func accelerateVelocity(velocity, desired, acceleration, dt float32) float32 {
+	step := acceleration * dt
+	if velocity < desired {
+		return min(velocity+step, desired)
+	}
+	return max(velocity-step, desired)
+}
+
+// dampVelocity decays a velocity towards zero at an exponential rate, so that it halves every ln(2)/damping seconds whatever the frame rate.
+func dampVelocity(velocity, damping, dt float32) float32 {
+	velocity *= float32(math.Exp(float64(-damping * dt)))
+	if velocity > -stoppedVelocity && velocity < stoppedVelocity {
+		return 0
+	}
+	return velocity
+}
+
+// stoppedVelocity is the speed below which a coasting camera is treated as stopped.
+const stoppedVelocity = 1e-4
+
+// SetDesiredVelocity sets the velocity that Update moves the camera towards, along a direction.
+// Setting a direction also sets its opposite: SetDesiredVelocity(Backward, 2) is the same as SetDesiredVelocity(Forward, -2).  Set the speed to zero to let the camera coast to a stop.
+func (c *Camera) SetDesiredVelocity(direction Direction, speed float32) {
+	axis, sign, ok := directionAxis(direction)
+	if !ok {
+		return
+	}
+	c.desiredVelocity[axis] = sign * speed
+}
+
+// ClearDesiredVelocity sets every desired velocity to zero, so that the camera coasts to a stop.
+func (c *Camera) ClearDesiredVelocity() {
+	c.desiredVelocity = [6]float32{}
+}
+
+// Velocity returns the current velocity along a direction.  It is negative when the camera is moving in the opposite direction.
+func (c *Camera) Velocity(direction Direction) float32 {
+	axis, sign, ok := directionAxis(direction)
+	if !ok {
+		return 0
+	}
+	return sign * c.velocity[axis]
+}
+
+// Halt stops the camera immediately, clearing both the current and desired velocities.
+func (c *Camera) Halt() {
+	c.velocity = [6]float32{}
+	c.desiredVelocity = [6]float32{}
+}
+
+// directionAxis returns the axis index for a direction, and the sign of the direction along that axis.
+func directionAxis(direction Direction) (axis int, sign float32, ok bool) {
+	if direction < Forward || direction > RollRight {
+		return 0, 0, false
+	}
+	sign = 1
+	if direction%2 == 1 {
+		sign = -1
+	}
+	return int(direction / 2), sign, true
+}
@@ -0,0 +1,96 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func runUpdates(camera *Camera, seconds, dt float32) {
+	steps := int(math.Round(float64(seconds / dt)))
+	for i := 0; i < steps; i++ {
+		camera.Update(dt)
+	}
+}
+
+func TestUpdateAcceleratesToMaxSpeed(t *testing.T) {
+	camera := New(FPS)
+	camera.SetDesiredVelocity(Forward, 100)
+	runUpdates(camera, 0.25, 0.01)
+	assertFloat(t, camera.Velocity(Forward), 5)
+	runUpdates(camera, 1, 0.01)
+	assertFloat(t, camera.Velocity(Forward), camera.MaxSpeed)
+	assertFloat(t, camera.Velocity(Backward), -camera.MaxSpeed)
+}
+
+func TestUpdateDampsToStop(t *testing.T) {
+	camera := New(FPS)
+	camera.SetDesiredVelocity(Right, 4)
+	runUpdates(camera, 1, 0.01)
+	camera.SetDesiredVelocity(Right, 0)
+	camera.Update(float32(math.Ln2) / camera.Damping)
+	assertFloat(t, camera.Velocity(Right), 2)
+
+	runUpdates(camera, 5, 0.01)
+	if camera.Velocity(Right) != 0 {
+		t.Errorf("expected the camera to coast to a stop, got %v", camera.Velocity(Right))
+	}
+	stopped := camera.Position
+	camera.Update(0.1)
+	assertVec3(t, camera.Position, stopped)
+}
+
+func TestUpdateIsFrameRateIndependent(t *testing.T) {
+	for _, mode := range []Mode{Museum, FPS, RTS} {
+		t.Run(mode.String(), func(t *testing.T) {
+			positions := make([]mgl32.Vec3, 0, 2)
+			for _, dt := range []float32{1.0 / 30, 1.0 / 240} {
+				camera := New(mode)
+				camera.SetDesiredVelocity(Forward, 3)
+				camera.SetDesiredVelocity(YawLeft, 0.5)
+				runUpdates(camera, 1, dt)
+				camera.ClearDesiredVelocity()
+				runUpdates(camera, 1, dt)
+				positions = append(positions, camera.Position)
+			}
+			if !positions[0].ApproxEqualThreshold(positions[1], 0.02) {
+				t.Errorf("expected the same path at 30 and 240 updates per second, got %v and %v", positions[0], positions[1])
+			}
+		})
+	}
+}
+
+func TestUpdateWithoutInertia(t *testing.T) {
+	camera := New(FPS)
+	camera.Acceleration = 0
+	camera.Damping = 0
+	camera.SetDesiredVelocity(Backward, 2)
+	camera.Update(0.5)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 0, 6})
+	camera.SetDesiredVelocity(Backward, 0)
+	camera.Update(0.5)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 0, 6})
+}
+
+func TestHalt(t *testing.T) {
+	camera := New(FPS)
+	camera.SetDesiredVelocity(PitchUp, 1)
+	camera.Update(0.1)
+	if camera.Velocity(PitchUp) == 0 {
+		t.Fatal("expected the camera to start turning")
+	}
+	camera.Halt()
+	if camera.Velocity(PitchUp) != 0 {
+		t.Error("expected Halt to stop the camera")
+	}
+	camera.Update(0.1)
+	if camera.Velocity(PitchUp) != 0 {
+		t.Error("expected Halt to clear the desired velocity")
+	}
+
+	camera.SetDesiredVelocity(Direction(20), 1)
+	if camera.Velocity(Direction(20)) != 0 {
+		t.Error("expected unknown directions to be ignored")
+	}
+}
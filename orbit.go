@@ -0,0 +1,180 @@
+package sceneCamera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// OrbitStyle selects how a museum mode camera orbits its target.
+type OrbitStyle int
+
+// Orbit styles.  The zero value is WorldAxes.
+const (
+	// WorldAxes orbits left and right around the world Y axis, and up and down around the world X axis, as museum mode always has.  It suits Y-up scenes viewed from the front.
+	WorldAxes OrbitStyle = iota
+	// Turntable yaws around the camera's Up vector and pitches towards it, stopping short of the poles at OrbitPitchLimit, so the view never turns upside down.
+	Turntable
+	// Trackball rotates freely in the camera's own axes, like rolling a ball under the mouse.  The camera's Up vector turns with it.
+	Trackball
+)
+
+// String returns the name of the orbit style.
+func (o OrbitStyle) String() string {
+	switch o {
+	case WorldAxes:
+		return "WorldAxes"
+	case Turntable:
+		return "Turntable"
+	case Trackball:
+		return "Trackball"
+	}
+	return fmt.Sprintf("OrbitStyle(%d)", int(o))
+}
+
+// SetOrbitStyle chooses how a museum mode camera orbits its target.
+func (c *Camera) SetOrbitStyle(style OrbitStyle) {
+	c.Orbit = style
+}
+
+// ArcballRotation returns the rotation of the scene, about the target, for a mouse drag between two pixels measured from the top left of the screen.
+// The rotation is in world space.  The camera orbits the other way, so Arcball applies the inverse of this rotation to the camera.
+//
+// With WorldAxes, horizontal movement spins the scene around the world Y axis, and vertical movement tilts it around the world X axis.
+// With Trackball, the pixels are mapped onto a virtual ball filling the screen, and the rotation turns the first point on the ball to the second.
+// With Turntable, horizontal movement spins the scene around Up, and vertical movement tilts it.  Dragging the height of the screen turns it by half a revolution.
+// Arcball stops the tilt at OrbitPitchLimit, but the returned rotation does not.
+func (c *Camera) ArcballRotation(fromX, fromY, toX, toY float32) mgl32.Quat {
+	yaw, pitch, rotation := c.arcball(fromX, fromY, toX, toY)
+	switch c.Orbit {
+	case WorldAxes:
+		return worldAxesRotation(yaw, pitch).Inverse()
+	case Turntable:
+		//The inverse of the camera's orbit, ignoring OrbitPitchLimit
+		_, right, _ := c.cameraAxes()
+		return mgl32.QuatRotate(yaw, c.Up.Normalize()).Mul(mgl32.QuatRotate(-pitch, right)).Inverse()
+	}
+	return rotation
+}
+
+// Arcball orbits the camera around its target for a mouse drag between two pixels, so the scene appears to turn with the mouse.  See ArcballRotation.
+func (c *Camera) Arcball(fromX, fromY, toX, toY float32) {
+	yaw, pitch, rotation := c.arcball(fromX, fromY, toX, toY)
+	switch c.Orbit {
+	case WorldAxes:
+		c.orbitWorldAxes(yaw, pitch)
+	case Turntable:
+		c.orbitTurntable(yaw, pitch)
+	default:
+		c.orbitRotate(rotation.Inverse())
+	}
+}
+
+// arcball works out the turntable angles, and the trackball rotation, for a drag.
+func (c *Camera) arcball(fromX, fromY, toX, toY float32) (yaw, pitch float32, rotation mgl32.Quat) {
+	if c.Screenheight == 0 {
+		return 0, 0, mgl32.QuatIdent()
+	}
+	//The camera swings the opposite way to the drag
+	perPixel := float32(math.Pi) / c.Screenheight
+	yaw = -(toX - fromX) * perPixel
+	pitch = (toY - fromY) * perPixel
+
+	from := c.arcballPoint(fromX, fromY)
+	to := c.arcballPoint(toX, toY)
+	toWorld := c.Orientation.Conjugate()
+	rotation = mgl32.QuatBetweenVectors(toWorld.Rotate(from), toWorld.Rotate(to))
+	return yaw, pitch, rotation
+}
+
+// arcballPoint maps a pixel onto the virtual trackball, in camera space.  The ball fills the shorter side of the screen.
+// Outside the ball the point is on a hyperbolic sheet instead, so dragging there still turns smoothly.
+func (c *Camera) arcballPoint(x, y float32) mgl32.Vec3 {
+	size := min(c.Screenwidth, c.Screenheight)
+	if size <= 0 {
+		return mgl32.Vec3{0, 0, 1}
+	}
+	px := (2*x - c.Screenwidth) / size
+	py := (c.Screenheight - 2*y) / size
+	squared := px*px + py*py
+	if squared <= 0.5 {
+		return mgl32.Vec3{px, py, float32(math.Sqrt(float64(1 - squared)))}.Normalize()
+	}
+	return mgl32.Vec3{px, py, 0.5 / float32(math.Sqrt(float64(squared)))}.Normalize()
+}
+
+// worldAxesRotation returns the WorldAxes orbit of the camera: yaw radians around the world Y axis, then pitch radians upwards around the world X axis.
+func worldAxesRotation(yaw, pitch float32) mgl32.Quat {
+	return mgl32.QuatRotate(yaw, mgl32.Vec3{0, 1, 0}).Mul(mgl32.QuatRotate(-pitch, mgl32.Vec3{1, 0, 0}))
+}
+
+// orbitWorldAxes swings the camera around the target in fixed world axes, keeping its Up vector.
+func (c *Camera) orbitWorldAxes(yaw, pitch float32) {
+	c.Position = c.Target.Add(worldAxesRotation(yaw, pitch).Rotate(c.Position.Sub(c.Target)))
+	c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
+}
+
+// orbitTurntable swings the camera around the target: yaw radians around Up, and pitch radians towards Up.
+// The pitch stops at OrbitPitchLimit above or below the plane at right angles to Up.
+func (c *Camera) orbitTurntable(yaw, pitch float32) {
+	up := c.Up.Normalize()
+	offset := c.Position.Sub(c.Target)
+	distance := offset.Len()
+	if distance == 0 {
+		return
+	}
+	direction := offset.Mul(1 / distance)
+
+	//Split the direction into height along Up, and a heading at right angles to it
+	elevation := float32(math.Asin(float64(mgl32.Clamp(direction.Dot(up), -1, 1))))
+	heading := direction.Sub(up.Mul(direction.Dot(up)))
+	if heading.Len() < 1e-6 {
+		//Looking straight along Up, so take the heading from the camera's own up vector
+		_, _, cameraUp := c.cameraAxes()
+		heading = cameraUp.Mul(-direction.Dot(up))
+	}
+	heading = mgl32.QuatRotate(yaw, up).Rotate(heading.Normalize())
+
+	//Stop just short of the poles even without a limit, where the view direction would be parallel to Up
+	limit := c.OrbitPitchLimit
+	if limit <= 0 || limit > maxPitch {
+		limit = maxPitch
+	}
+	elevation = mgl32.Clamp(elevation+pitch, -limit, limit)
+	sin, cos := math.Sincos(float64(elevation))
+	direction = heading.Mul(float32(cos)).Add(up.Mul(float32(sin)))
+
+	c.Position = c.Target.Add(direction.Mul(distance))
+	c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
+}
+
+// cameraAxes returns the camera's forward, right and up vectors from its orientation.  Unlike ForwardsVector and friends, they do not depend on Target or Up.
+func (c *Camera) cameraAxes() (forward, right, up mgl32.Vec3) {
+	toWorld := c.Orientation.Conjugate()
+	return toWorld.Rotate(mgl32.Vec3{0, 0, -1}), toWorld.Rotate(mgl32.Vec3{1, 0, 0}), toWorld.Rotate(mgl32.Vec3{0, 1, 0})
+}
+
+// orbitRotate turns the camera, and its up vector, around the target by a world space rotation.
+func (c *Camera) orbitRotate(rotation mgl32.Quat) {
+	_, _, up := c.cameraAxes()
+	up = rotation.Rotate(up)
+	c.Position = c.Target.Add(rotation.Rotate(c.Position.Sub(c.Target)))
+	c.Up = up
+	c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
+}
+
+// orbitMuseum orbits the camera for the Left, Right, Up and Down directions in museum mode, in the camera's orbit style.
+// yaw turns the camera around the target to the right, and pitch turns it upwards.
+func (c *Camera) orbitMuseum(yaw, pitch float32) {
+	switch c.Orbit {
+	case WorldAxes:
+		c.orbitWorldAxes(yaw, pitch)
+	case Turntable:
+		c.orbitTurntable(yaw, pitch)
+	default:
+		//In camera axes, so the orbit never reaches a pole
+		_, right, up := c.cameraAxes()
+		c.orbitRotate(mgl32.QuatRotate(yaw, up).Mul(mgl32.QuatRotate(-pitch, right)))
+	}
+}
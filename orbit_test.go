@@ -0,0 +1,157 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestOrbitStyleString(t *testing.T) {
+	for style, expected := range map[OrbitStyle]string{WorldAxes: "WorldAxes", Turntable: "Turntable", Trackball: "Trackball", OrbitStyle(7): "OrbitStyle(7)"} {
+		if actual := style.String(); actual != expected {
+			t.Errorf("expected %q, got %q", expected, actual)
+		}
+	}
+}
+
+func TestWorldAxesOrbit(t *testing.T) {
+	//The default style turns the camera around the world axes, as museum mode always has
+	for _, move := range []struct {
+		direction Direction
+		rotation  mgl32.Mat4
+	}{
+		{Left, mgl32.HomogRotate3DY(0.3)},
+		{Right, mgl32.HomogRotate3DY(-0.3)},
+		{Up, mgl32.HomogRotate3DX(-0.3)},
+		{Down, mgl32.HomogRotate3DX(0.3)},
+	} {
+		t.Run(move.direction.String(), func(t *testing.T) {
+			camera := New(Museum)
+			camera.SetPosition(1, 2, 5)
+			camera.Move(move.direction, 0.3)
+			assertVec3(t, camera.Position, move.rotation.Mul4x1(mgl32.Vec4{1, 2, 5, 0}).Vec3())
+			assertVec3(t, camera.Up, mgl32.Vec3{0, 1, 0})
+		})
+	}
+
+	//Dragging right swings the camera left around Y, and the rotation is the opposite of the camera's
+	camera := New(Museum)
+	camera.SetPosition(3, 2, 4)
+	rotation := camera.ArcballRotation(0, 0, camera.Screenheight/2, 0)
+	camera.Arcball(0, 0, camera.Screenheight/2, 0)
+	assertVec3(t, camera.Position, mgl32.Vec3{-4, 2, 3})
+	assertVec3(t, rotation.Rotate(camera.Position), mgl32.Vec3{3, 2, 4})
+}
+
+func TestTurntableOrbitsAroundUp(t *testing.T) {
+	camera := zUpMuseumPose.camera()
+	camera.SetOrbitStyle(Turntable)
+	camera.Move(Left, math.Pi/2)
+	//Yawing keeps the height, whichever way up the world is
+	assertVec3Near(t, camera.Position, mgl32.Vec3{5, 0, 0}, 1e-4)
+	assertVec3(t, camera.UpwardsVector(), mgl32.Vec3{0, 0, 1})
+
+	camera.Move(Up, math.Pi/6)
+	assertVec3Near(t, camera.Position, mgl32.Vec3{5 * float32(math.Cos(math.Pi/6)), 0, 2.5}, 1e-4)
+	assertFloat(t, camera.Position.Len(), 5)
+}
+
+func TestTurntableClampsPitch(t *testing.T) {
+	for _, direction := range []Direction{Up, Down} {
+		t.Run(direction.String(), func(t *testing.T) {
+			camera := zUpMuseumPose.camera()
+			camera.SetOrbitStyle(Turntable)
+			camera.OrbitPitchLimit = 1
+			camera.Move(direction, 3)
+			elevation := math.Asin(float64(camera.Position.Normalize().Z()))
+			if math.Abs(math.Abs(elevation)-1) > 1e-4 {
+				t.Errorf("expected the elevation to stop at the limit, got %v", elevation)
+			}
+			if camera.UpwardsVector().Z() <= 0 {
+				t.Errorf("expected the view to stay upright, got up %v", camera.UpwardsVector())
+			}
+		})
+	}
+
+	//Without a limit, the camera still stops short of the pole
+	camera := zUpMuseumPose.camera()
+	camera.SetOrbitStyle(Turntable)
+	camera.OrbitPitchLimit = 0
+	camera.Move(Up, 10)
+	assertFiniteMat4(t, camera.ViewMatrix())
+	if camera.Position.Z() < 4.99 {
+		t.Errorf("expected the camera to reach the pole, got %v", camera.Position)
+	}
+}
+
+func TestTrackballOrbitsOverThePoles(t *testing.T) {
+	camera := zUpMuseumPose.camera()
+	camera.SetOrbitStyle(Trackball)
+	const steps = 10
+	for i := 0; i < steps; i++ {
+		camera.Move(Up, math.Pi/steps)
+	}
+	//Half a turn over the top leaves the camera on the far side, upside down
+	assertVec3Near(t, camera.Position, mgl32.Vec3{0, 5, 0}, 1e-4)
+	assertVec3Near(t, camera.UpwardsVector(), mgl32.Vec3{0, 0, -1}, 1e-4)
+	assertVec3Near(t, camera.ForwardsVector(), mgl32.Vec3{0, -1, 0}, 1e-4)
+
+	camera.Move(Right, math.Pi/2)
+	assertFloat(t, camera.Position.Len(), 5)
+	assertVec3(t, camera.Target, mgl32.Vec3{})
+}
+
+func TestArcball(t *testing.T) {
+	for _, style := range []OrbitStyle{Turntable, Trackball} {
+		t.Run(style.String(), func(t *testing.T) {
+			camera := zUpMuseumPose.camera()
+			camera.SetOrbitStyle(style)
+			centreX, centreY := camera.Screenwidth/2, camera.Screenheight/2
+			if rotation := camera.ArcballRotation(centreX, centreY, centreX, centreY); !rotation.OrientationEqualThreshold(mgl32.QuatIdent(), 1e-6) {
+				t.Errorf("expected no rotation without a drag, got %v", rotation)
+			}
+
+			//Dragging right turns the front of the scene to the right, so the camera swings left
+			before := camera.Position
+			rotation := camera.ArcballRotation(centreX, centreY, centreX+100, centreY)
+			front := before.Normalize()
+			if rotation.Rotate(front).Dot(camera.RightWardsVector()) <= 0 {
+				t.Errorf("expected the front of the scene to turn right, got %v", rotation.Rotate(front))
+			}
+			camera.Arcball(centreX, centreY, centreX+100, centreY)
+			assertVec3(t, camera.Position, rotation.Inverse().Rotate(before))
+			if camera.Position.X() >= 0 {
+				t.Errorf("expected the camera to swing left, got %v", camera.Position)
+			}
+
+			//Dragging down tilts the top of the scene towards the camera
+			before = camera.Position
+			camera.Arcball(centreX, centreY, centreX, centreY+100)
+			if camera.Position.Z() <= before.Z() {
+				t.Errorf("expected the camera to rise, got %v from %v", camera.Position, before)
+			}
+			assertFloat(t, camera.Position.Len(), 5)
+		})
+	}
+}
+
+func TestTurntableArcballScale(t *testing.T) {
+	camera := zUpMuseumPose.camera()
+	camera.SetOrbitStyle(Turntable)
+	//Dragging the height of the screen sideways is half a turn
+	camera.Arcball(0, 0, camera.Screenheight, 0)
+	assertVec3Near(t, camera.Position, mgl32.Vec3{0, 5, 0}, 1e-4)
+}
+
+func TestTrackballPointIsOnTheBall(t *testing.T) {
+	camera := New(Museum)
+	for _, pixel := range [][2]float32{{960, 540}, {0, 0}, {1920, 1080}, {1500, 100}} {
+		point := camera.arcballPoint(pixel[0], pixel[1])
+		assertFloat(t, point.Len(), 1)
+		if point.Z() <= 0 {
+			t.Errorf("expected the point for %v to face the camera, got %v", pixel, point)
+		}
+	}
+	assertVec3(t, camera.arcballPoint(960, 540), mgl32.Vec3{0, 0, 1})
+}
@@ -0,0 +1,321 @@
+package sceneCamera
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// PathInterpolation selects how a CameraPath moves the camera position between keyframes.
+type PathInterpolation int
+
+// Position interpolations.  The zero value is CatmullRom.
+const (
+	// CatmullRom passes a smooth curve through every keyframe position, with the tangents taken from the neighbouring keyframes.
+	CatmullRom PathInterpolation = iota
+	// Bezier uses each keyframe's OutControl and the next keyframe's InControl as the control points of a cubic Bezier curve.
+	Bezier
+	// Linear moves in straight lines between keyframes.
+	Linear
+)
+
+// String returns the name of the interpolation.
+func (p PathInterpolation) String() string {
+	switch p {
+	case CatmullRom:
+		return "CatmullRom"
+	case Bezier:
+		return "Bezier"
+	case Linear:
+		return "Linear"
+	}
+	return fmt.Sprintf("PathInterpolation(%d)", int(p))
+}
+
+// RotationInterpolation selects how a CameraPath turns the camera between keyframes.
+type RotationInterpolation int
+
+// Rotation interpolations.  The zero value is Squad.
+const (
+	// Squad is spherical cubic interpolation, which turns smoothly through each keyframe without a jolt in angular velocity.
+	Squad RotationInterpolation = iota
+	// Slerp turns at a constant rate between each pair of keyframes.
+	Slerp
+)
+
+// String returns the name of the interpolation.
+func (r RotationInterpolation) String() string {
+	switch r {
+	case Squad:
+		return "Squad"
+	case Slerp:
+		return "Slerp"
+	}
+	return fmt.Sprintf("RotationInterpolation(%d)", int(r))
+}
+
+// Keyframe is a camera pose at a moment in time, in seconds.
+type Keyframe struct {
+	Time float32
+	Pose
+	InControl  mgl32.Vec3 //The Bezier control point before this keyframe, in world space.  Only used with Bezier interpolation
+	OutControl mgl32.Vec3 //The Bezier control point after this keyframe, in world space.  Only used with Bezier interpolation
+}
+
+// pathSamplesPerSegment is the number of straight pieces used to measure the length of each segment, for constant speed paths.
+const pathSamplesPerSegment = 256
+
+// CameraPath is a scripted camera move, defined by keyframes.
+//
+// Positions follow the chosen PathInterpolation, and view directions (including roll from each keyframe's up vector) follow the chosen RotationInterpolation.
+// The FOV and the distance to the target change linearly.
+type CameraPath struct {
+	Interpolation PathInterpolation     //How the position moves between keyframes
+	Rotation      RotationInterpolation //How the orientation turns between keyframes
+	ConstantSpeed bool                  //Move at a constant speed along the whole path, using only the first and last keyframe times
+
+	keyframes    []Keyframe   //Sorted by time
+	orientations []mgl32.Quat //The orientation of each keyframe, each on the same side of the quaternion sphere as the one before
+	arcLengths   []float32    //The cumulative length of the path at each sample, for constant speed
+	arcMeasured  PathInterpolation
+}
+
+// NewCameraPath creates a path through the keyframes, which do not need to be in time order.
+func NewCameraPath(keyframes ...Keyframe) *CameraPath {
+	p := &CameraPath{}
+	for _, keyframe := range keyframes {
+		p.AddKeyframe(keyframe)
+	}
+	return p
+}
+
+// AddKeyframe adds a keyframe to the path, in time order.
+func (p *CameraPath) AddKeyframe(keyframe Keyframe) {
+	index := sort.Search(len(p.keyframes), func(i int) bool { return p.keyframes[i].Time > keyframe.Time })
+	p.keyframes = append(p.keyframes, Keyframe{})
+	copy(p.keyframes[index+1:], p.keyframes[index:])
+	p.keyframes[index] = keyframe
+
+	p.orientations = p.orientations[:0]
+	for i, key := range p.keyframes {
+		orientation := key.Orientation()
+		if i > 0 && orientation.Dot(p.orientations[i-1]) < 0 {
+			orientation = orientation.Scale(-1)
+		}
+		p.orientations = append(p.orientations, orientation)
+	}
+	p.arcLengths = nil
+}
+
+// Keyframes returns a copy of the keyframes, in time order.
+func (p *CameraPath) Keyframes() []Keyframe {
+	return append([]Keyframe(nil), p.keyframes...)
+}
+
+// Duration returns the time from the first keyframe to the last.
+func (p *CameraPath) Duration() float32 {
+	if len(p.keyframes) == 0 {
+		return 0
+	}
+	return p.keyframes[len(p.keyframes)-1].Time - p.keyframes[0].Time
+}
+
+// Sample moves the camera to the path's pose at time t.  Times outside the path hold the first or last pose.
+// The camera's position, target, up vector, orientation and FOV are all set.  A path with no keyframes leaves the camera unchanged.
+func (p *CameraPath) Sample(t float32, camera *Camera) {
+	if len(p.keyframes) == 0 {
+		return
+	}
+	pose, orientation := p.sample(t)
+	camera.Position = pose.Position
+	camera.Target = pose.Target
+	camera.Up = pose.Up
+	camera.FOV = pose.FOV
+	camera.Orientation = orientation
+}
+
+// PoseAt returns the path's pose at time t.  Times outside the path hold the first or last pose.
+func (p *CameraPath) PoseAt(t float32) Pose {
+	if len(p.keyframes) == 0 {
+		return Pose{}
+	}
+	pose, _ := p.sample(t)
+	return pose
+}
+
+func (p *CameraPath) sample(t float32) (Pose, mgl32.Quat) {
+	segment, u := p.locate(t)
+	if segment == len(p.keyframes)-1 {
+		return p.keyframes[segment].Pose, p.orientations[segment]
+	}
+
+	from, to := p.keyframes[segment], p.keyframes[segment+1]
+	position := p.position(segment, u)
+	orientation := p.orientation(segment, u)
+	targetDistance := lerp(from.Target.Sub(from.Position).Len(), to.Target.Sub(to.Position).Len(), u)
+	fov := lerp(from.FOV, to.FOV, u)
+	return poseFromOrientation(position, orientation, targetDistance, fov), orientation
+}
+
+// locate finds the segment that starts at keyframe index segment, and the fraction u of the way along it, for time t.
+// The last keyframe is returned as a segment of its own, with u of zero.
+func (p *CameraPath) locate(t float32) (segment int, u float32) {
+	last := len(p.keyframes) - 1
+	first := p.keyframes[0].Time
+	end := p.keyframes[last].Time
+	if last == 0 || t <= first {
+		return 0, 0
+	}
+	if t >= end {
+		return last, 0
+	}
+
+	if p.ConstantSpeed {
+		return p.locateDistance((t - first) / (end - first))
+	}
+
+	segment = sort.Search(last, func(i int) bool { return p.keyframes[i+1].Time > t }) // The first segment that ends after t
+	start, finish := p.keyframes[segment].Time, p.keyframes[segment+1].Time
+	if finish == start {
+		return segment, 0
+	}
+	return segment, (t - start) / (finish - start)
+}
+
+// locateDistance finds the segment and fraction at a fraction of the total length of the path.
+func (p *CameraPath) locateDistance(fraction float32) (segment int, u float32) {
+	if p.arcLengths == nil || p.arcMeasured != p.Interpolation {
+		p.measure()
+	}
+	total := p.arcLengths[len(p.arcLengths)-1]
+	if total == 0 {
+		return 0, 0
+	}
+	distance := fraction * total
+	//The first sample at or beyond the distance
+	sample := sort.Search(len(p.arcLengths), func(i int) bool { return p.arcLengths[i] >= distance })
+	if sample == 0 {
+		return 0, 0
+	}
+	before, after := p.arcLengths[sample-1], p.arcLengths[sample]
+	within := float32(0)
+	if after > before {
+		within = (distance - before) / (after - before)
+	}
+	position := (float32(sample-1) + within) / pathSamplesPerSegment
+	segment = min(int(position), len(p.keyframes)-2)
+	return segment, position - float32(segment)
+}
+
+// measure builds the table of cumulative path lengths used for constant speed.
+func (p *CameraPath) measure() {
+	segments := len(p.keyframes) - 1
+	p.arcLengths = make([]float32, 0, segments*pathSamplesPerSegment+1)
+	p.arcLengths = append(p.arcLengths, 0)
+	total := float32(0)
+	previous := p.keyframes[0].Position
+	for segment := 0; segment < segments; segment++ {
+		for sample := 1; sample <= pathSamplesPerSegment; sample++ {
+			point := p.position(segment, float32(sample)/pathSamplesPerSegment)
+			total += point.Sub(previous).Len()
+			p.arcLengths = append(p.arcLengths, total)
+			previous = point
+		}
+	}
+	p.arcMeasured = p.Interpolation
+}
+
+// position returns the position a fraction u of the way along a segment.
+func (p *CameraPath) position(segment int, u float32) mgl32.Vec3 {
+	from, to := p.keyframes[segment], p.keyframes[segment+1]
+	switch p.Interpolation {
+	case Linear:
+		return from.Position.Add(to.Position.Sub(from.Position).Mul(u))
+	case Bezier:
+		return bezier(from.Position, from.OutControl, to.InControl, to.Position, u)
+	}
+	//The curve ends are extended by repeating the end keyframes
+	before := p.keyframes[max(segment-1, 0)].Position
+	after := p.keyframes[min(segment+2, len(p.keyframes)-1)].Position
+	return catmullRom(before, from.Position, to.Position, after, u)
+}
+
+// orientation returns the orientation a fraction u of the way along a segment.
+func (p *CameraPath) orientation(segment int, u float32) mgl32.Quat {
+	from, to := p.orientations[segment], p.orientations[segment+1]
+	if p.Rotation == Slerp {
+		return mgl32.QuatSlerp(from, to, u)
+	}
+	before := p.orientations[max(segment-1, 0)]
+	after := p.orientations[min(segment+2, len(p.orientations)-1)]
+	return squad(from, to, squadControl(before, from, to), squadControl(from, to, after), u)
+}
+
+func lerp(from, to, u float32) float32 {
+	return from + (to-from)*u
+}
+
+// catmullRom evaluates a uniform Catmull-Rom spline between p1 and p2.
+func catmullRom(p0, p1, p2, p3 mgl32.Vec3, u float32) mgl32.Vec3 {
+	u2 := u * u
+	u3 := u2 * u
+	return p1.Mul(2).
+		Add(p2.Sub(p0).Mul(u)).
+		Add(p0.Mul(2).Sub(p1.Mul(5)).Add(p2.Mul(4)).Sub(p3).Mul(u2)).
+		Add(p1.Mul(3).Sub(p0).Sub(p2.Mul(3)).Add(p3).Mul(u3)).
+		Mul(0.5)
+}
+
+// bezier evaluates a cubic Bezier curve from p0 to p1, with control points c0 and c1.
+func bezier(p0, c0, c1, p1 mgl32.Vec3, u float32) mgl32.Vec3 {
+	v := 1 - u
+	return p0.Mul(v * v * v).
+		Add(c0.Mul(3 * v * v * u)).
+		Add(c1.Mul(3 * v * u * u)).
+		Add(p1.Mul(u * u * u))
+}
+
+// squad is spherical cubic interpolation from q1 to q2, with the inner control quaternions s1 and s2.
+func squad(q1, q2, s1, s2 mgl32.Quat, u float32) mgl32.Quat {
+	return mgl32.QuatSlerp(mgl32.QuatSlerp(q1, q2, u), mgl32.QuatSlerp(s1, s2, u), 2*u*(1-u))
+}
+
+// squadControl returns the inner control quaternion for q, between its neighbours, which makes squad curves join smoothly.
+func squadControl(previous, q, next mgl32.Quat) mgl32.Quat {
+	inverse := q.Inverse()
+	sum := quatLog(inverse.Mul(next)).Add(quatLog(inverse.Mul(previous)))
+	return q.Mul(quatExp(sum.Scale(-0.25))).Normalize()
+}
+
+// quatLog returns the logarithm of a unit quaternion, a pure quaternion holding half the rotation angle along the rotation axis.
+func quatLog(q mgl32.Quat) mgl32.Quat {
+	length := q.V.Len()
+	if length < 1e-6 {
+		return mgl32.Quat{}
+	}
+	angle := float32(math.Atan2(float64(length), float64(q.W)))
+	return mgl32.Quat{V: q.V.Mul(angle / length)}
+}
+
+// quatExp is the inverse of quatLog.
+func quatExp(q mgl32.Quat) mgl32.Quat {
+	angle := q.V.Len()
+	if angle < 1e-6 {
+		return mgl32.QuatIdent()
+	}
+	sin, cos := math.Sincos(float64(angle))
+	return mgl32.Quat{W: float32(cos), V: q.V.Mul(float32(sin) / angle)}
+}
+
+// translate moves every keyframe of the path by offset.
+func (p *CameraPath) translate(offset mgl32.Vec3) {
+	for i := range p.keyframes {
+		keyframe := &p.keyframes[i]
+		keyframe.Position = keyframe.Position.Add(offset)
+		keyframe.Target = keyframe.Target.Add(offset)
+		keyframe.InControl = keyframe.InControl.Add(offset)
+		keyframe.OutControl = keyframe.OutControl.Add(offset)
+	}
+}
@@ -0,0 +1,125 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func testPath() *CameraPath {
+	up := mgl32.Vec3{0, 0, 1}
+	//Added out of order, to check sorting
+	return NewCameraPath(
+		Keyframe{Time: 2, Pose: Pose{Position: mgl32.Vec3{10, 0, 5}, Target: mgl32.Vec3{10, 10, 5}, Up: mgl32.Vec3{1, 0, 1}.Normalize(), FOV: 1}},
+		Keyframe{Time: 0, Pose: Pose{Position: mgl32.Vec3{0, 0, 5}, Target: mgl32.Vec3{10, 0, 5}, Up: up, FOV: 1}},
+		Keyframe{Time: 1, Pose: Pose{Position: mgl32.Vec3{5, 1, 8}, Target: mgl32.Vec3{15, 1, 8}, Up: up, FOV: 0.5}},
+		Keyframe{Time: 6, Pose: Pose{Position: mgl32.Vec3{10, 30, 5}, Target: mgl32.Vec3{0, 30, 5}, Up: up, FOV: 1}},
+	)
+}
+
+func TestCameraPathPassesThroughKeyframes(t *testing.T) {
+	for _, interpolation := range []PathInterpolation{CatmullRom, Linear} {
+		for _, rotation := range []RotationInterpolation{Squad, Slerp} {
+			t.Run(interpolation.String()+"-"+rotation.String(), func(t *testing.T) {
+				path := testPath()
+				path.Interpolation = interpolation
+				path.Rotation = rotation
+				camera := New(FPS)
+				for _, keyframe := range path.Keyframes() {
+					path.Sample(keyframe.Time, camera)
+					assertVec3(t, camera.Position, keyframe.Position)
+					assertVec3(t, camera.Target, keyframe.Target)
+					assertVec3Near(t, camera.UpwardsVector(), keyframe.Up, 1e-4)
+					assertFloat(t, camera.FOV, keyframe.FOV)
+					if !camera.Orientation.OrientationEqualThreshold(keyframe.Orientation(), 1e-4) {
+						t.Errorf("expected orientation %v at %v, got %v", keyframe.Orientation(), keyframe.Time, camera.Orientation)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCameraPathInterpolation(t *testing.T) {
+	path := testPath()
+	path.Interpolation = Linear
+	pose := path.PoseAt(0.5)
+	assertVec3(t, pose.Position, mgl32.Vec3{2.5, 0.5, 6.5})
+	assertFloat(t, pose.FOV, 0.75)
+
+	path.Interpolation = Bezier
+	keyframes := path.Keyframes()
+	//Control points a third of the way along a straight line reproduce linear motion
+	path = NewCameraPath(
+		Keyframe{Time: 0, Pose: keyframes[0].Pose, OutControl: mgl32.Vec3{0, 0, 6}},
+		Keyframe{Time: 1, Pose: keyframes[1].Pose, InControl: mgl32.Vec3{5, 1, 2}},
+	)
+	path.Interpolation = Bezier
+	expected := bezier(keyframes[0].Position, mgl32.Vec3{0, 0, 6}, mgl32.Vec3{5, 1, 2}, keyframes[1].Position, 0.5)
+	assertVec3(t, path.PoseAt(0.5).Position, expected)
+
+	assertVec3(t, path.PoseAt(-1).Position, keyframes[0].Position)
+	assertVec3(t, path.PoseAt(10).Position, keyframes[1].Position)
+}
+
+func TestCameraPathRotationIsSmooth(t *testing.T) {
+	path := testPath()
+	camera := New(FPS)
+	previous := path.PoseAt(0).Orientation()
+	for step := 1; step <= 600; step++ {
+		path.Sample(float32(step)/100, camera)
+		if math.IsNaN(float64(camera.Orientation.W)) {
+			t.Fatalf("orientation is not a number at %v", float32(step)/100)
+		}
+		if !camera.Orientation.OrientationEqualThreshold(previous, 0.01) {
+			t.Errorf("orientation jumped at %v", float32(step)/100)
+		}
+		previous = camera.Orientation
+	}
+}
+
+func TestCameraPathConstantSpeed(t *testing.T) {
+	path := testPath()
+	path.ConstantSpeed = true
+	previous := path.PoseAt(0).Position
+	steps := 600
+	distances := make([]float32, 0, steps)
+	for step := 1; step <= steps; step++ {
+		position := path.PoseAt(path.Duration() * float32(step) / float32(steps)).Position
+		distances = append(distances, position.Sub(previous).Len())
+		previous = position
+	}
+	total := float32(0)
+	for _, distance := range distances {
+		total += distance
+	}
+	expected := total / float32(steps)
+	for step, distance := range distances {
+		if math.Abs(float64(distance-expected)) > 0.02*float64(expected) {
+			t.Fatalf("expected a distance of %v on each step, got %v on step %d", expected, distance, step)
+		}
+	}
+	assertVec3(t, previous, mgl32.Vec3{10, 30, 5})
+}
+
+func TestCameraPathEmpty(t *testing.T) {
+	path := NewCameraPath()
+	camera := New(FPS)
+	original := camera.Position
+	path.Sample(1, camera)
+	assertVec3(t, camera.Position, original)
+	if path.Duration() != 0 {
+		t.Errorf("expected an empty path to have no duration, got %v", path.Duration())
+	}
+}
+
+func TestQuatLogExp(t *testing.T) {
+	q := mgl32.QuatRotate(1.2, mgl32.Vec3{1, 2, 3}.Normalize())
+	if !quatExp(quatLog(q)).ApproxEqualThreshold(q, 1e-5) {
+		t.Errorf("expected exp(log(q)) to be q")
+	}
+	if !quatExp(quatLog(mgl32.QuatIdent())).ApproxEqual(mgl32.QuatIdent()) {
+		t.Errorf("expected exp(log(1)) to be 1")
+	}
+}
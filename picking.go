@@ -0,0 +1,136 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// ScreenRay returns the world-space ray through a pixel, for mouse picking.
+// x and y are measured in pixels from the top left of the screen, which is Screenwidth by Screenheight pixels.
+//
+// The ray starts on the near clipping plane, and direction is a unit vector.  For perspective projections the ray also passes through the camera position.
+func (c *Camera) ScreenRay(x, y float32) (origin, direction mgl32.Vec3) {
+	return unprojectRay(c.InverseViewProjectionMatrix(), c.Projection, c.screenRect().toNDC(x, y, c.Screenheight))
+}
+
+// EyeScreenRay is ScreenRay for one eye of a stereo layout.  x and y are measured from the top left of the whole output, as for ScreenRay,
+// and are mapped through the eye's viewport in the layout, as StereoViews places it.  The layout is ignored for MonoEye.
+// It returns a *ValidationError instead of panicking if the eye's projection cannot be built, for example while a minimised window has a zero size, or ErrUnknownStereoLayout.
+func (c *Camera) EyeScreenRay(eye Eye, layout StereoLayout, x, y float32) (origin, direction mgl32.Vec3, err error) {
+	if eye != LeftEye && eye != RightEye {
+		if _, err := c.ProjectionMatrixChecked(); err != nil {
+			return origin, direction, err
+		}
+		origin, direction = c.ScreenRay(x, y)
+		return origin, direction, nil
+	}
+	view, err := c.stereoView(eye, layout)
+	if err != nil {
+		return origin, direction, err
+	}
+	inverse := view.Projection.Mul4(view.View).Inv()
+	origin, direction = unprojectRay(inverse, Perspective, viewportRect(view.Viewport).toNDC(x, y, c.Screenheight))
+	return origin, direction, nil
+}
+
+// WorldToScreen projects a world-space point to pixel coordinates, measured from the top left of the screen.
+// visible is false when the point is behind the camera, or outside the view frustum.  The coordinates are still returned for points that are in front of the camera but off screen, so labels can be clamped to the screen edge.
+func (c *Camera) WorldToScreen(p mgl32.Vec3) (screen mgl32.Vec2, visible bool) {
+	ndc, visible, inFront := projectPoint(c.ViewProjectionMatrix(), c.Projection, p)
+	if !inFront {
+		return mgl32.Vec2{}, false
+	}
+	return c.screenRect().fromNDC(ndc, c.Screenheight), visible
+}
+
+// EyeWorldToScreen is WorldToScreen for one eye of a stereo layout.  The coordinates are measured from the top left of the whole output, and land in the eye's viewport, as for EyeScreenRay.
+// visible is also false for points outside the eye's viewport.
+// It returns a *ValidationError instead of panicking if the eye's projection cannot be built, or ErrUnknownStereoLayout.
+func (c *Camera) EyeWorldToScreen(eye Eye, layout StereoLayout, p mgl32.Vec3) (screen mgl32.Vec2, visible bool, err error) {
+	if eye != LeftEye && eye != RightEye {
+		if _, err := c.ProjectionMatrixChecked(); err != nil {
+			return screen, false, err
+		}
+		screen, visible = c.WorldToScreen(p)
+		return screen, visible, nil
+	}
+	view, err := c.stereoView(eye, layout)
+	if err != nil {
+		return screen, false, err
+	}
+	ndc, visible, inFront := projectPoint(view.Projection.Mul4(view.View), Perspective, p)
+	if !inFront {
+		return mgl32.Vec2{}, false, nil
+	}
+	return viewportRect(view.Viewport).fromNDC(ndc, c.Screenheight), visible, nil
+}
+
+// stereoView returns the eye's view in the layout.
+func (c *Camera) stereoView(eye Eye, layout StereoLayout) (StereoView, error) {
+	views, err := c.StereoViews(layout)
+	if eye == RightEye {
+		return views[1], err
+	}
+	return views[0], err
+}
+
+// pixelRect is a viewport in pixels, measured from the bottom left of the output as gl.Viewport expects.
+type pixelRect struct {
+	x, y, width, height float32
+}
+
+// screenRect returns the whole screen.
+func (c *Camera) screenRect() pixelRect {
+	return pixelRect{0, 0, c.Screenwidth, c.Screenheight}
+}
+
+// viewportRect returns the viewport as a pixelRect.
+func viewportRect(v Viewport) pixelRect {
+	return pixelRect{float32(v.X), float32(v.Y), float32(v.Width), float32(v.Height)}
+}
+
+// toNDC converts a pixel position, measured from the top left of an output outputHeight pixels high, to normalised device coordinates in the rectangle.
+func (r pixelRect) toNDC(x, y, outputHeight float32) mgl32.Vec2 {
+	//Pixel rows count down from the top, but viewports count up from the bottom
+	return mgl32.Vec2{
+		2*(x-r.x)/r.width - 1,
+		2*(outputHeight-y-r.y)/r.height - 1,
+	}
+}
+
+// fromNDC is the inverse of toNDC.
+func (r pixelRect) fromNDC(ndc mgl32.Vec3, outputHeight float32) mgl32.Vec2 {
+	return mgl32.Vec2{
+		r.x + (ndc.X()+1)/2*r.width,
+		outputHeight - r.y - (ndc.Y()+1)/2*r.height,
+	}
+}
+
+// unprojectRay returns the world-space ray through a point in normalised device coordinates, given the inverse of the view-projection matrix and the projection kind it was built with.
+func unprojectRay(inverse mgl32.Mat4, kind ProjectionKind, ndc mgl32.Vec2) (origin, direction mgl32.Vec3) {
+	//Unproject a point on the near plane, and a second point further along the same ray.
+	//The second depth is finite even for infinite projections.
+	nearDepth, innerDepth := float32(-1), float32(0)
+	if kind.reversedZ() {
+		nearDepth, innerDepth = 1, 0.5
+	}
+	origin = mgl32.TransformCoordinate(mgl32.Vec3{ndc.X(), ndc.Y(), nearDepth}, inverse)
+	inner := mgl32.TransformCoordinate(mgl32.Vec3{ndc.X(), ndc.Y(), innerDepth}, inverse)
+	return origin, inner.Sub(origin).Normalize()
+}
+
+// projectPoint returns where a world-space point lands in normalised device coordinates, given the view-projection matrix and the projection kind it was built with.
+// inFront is false when the point is behind the camera, and visible is true when it is inside the view frustum.
+func projectPoint(transform mgl32.Mat4, kind ProjectionKind, p mgl32.Vec3) (ndc mgl32.Vec3, visible, inFront bool) {
+	clip := transform.Mul4x1(p.Vec4(1))
+	if clip.W() <= 0 {
+		//Behind the camera
+		return mgl32.Vec3{}, false, false
+	}
+	ndc = clip.Vec3().Mul(1 / clip.W())
+	minDepth := float32(-1)
+	if kind.reversedZ() {
+		minDepth = 0
+	}
+	visible = ndc.X() >= -1 && ndc.X() <= 1 &&
+		ndc.Y() >= -1 && ndc.Y() <= 1 &&
+		ndc.Z() >= minDepth && ndc.Z() <= 1
+	return ndc, visible, true
+}
@@ -0,0 +1,184 @@
+package sceneCamera
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScreenRayThroughCentre(t *testing.T) {
+	for _, kind := range []ProjectionKind{Perspective, Orthographic, ReversedZ, InfiniteFar, ReversedZInfiniteFar} {
+		t.Run(kind.String(), func(t *testing.T) {
+			camera := New(FPS)
+			camera.SetProjection(kind)
+			origin, direction := camera.ScreenRay(camera.Screenwidth/2, camera.Screenheight/2)
+			assertVec3(t, direction, mgl32.Vec3{0, 0, -1})
+			if !origin.ApproxEqualThreshold(mgl32.Vec3{0, 0, 5 - camera.Near}, 1e-3) {
+				t.Errorf("expected ray to start on the near plane, got %v", origin)
+			}
+		})
+	}
+}
+
+func TestScreenRayRoundTrip(t *testing.T) {
+	for _, kind := range []ProjectionKind{Perspective, Orthographic, ReversedZ, InfiniteFar, ReversedZInfiniteFar} {
+		for _, eye := range []Eye{MonoEye, LeftEye, RightEye} {
+			t.Run(kind.String()+"-"+eye.String(), func(t *testing.T) {
+				camera := New(FPS)
+				camera.SetProjection(kind)
+				camera.SetPosition(3, 2, 7)
+				camera.LookAt(0, 0, 0)
+				pixel := mgl32.Vec2{700, 200}
+
+				origin, direction, err := camera.EyeScreenRay(eye, FullSideBySide, pixel.X(), pixel.Y())
+				if err != nil {
+					t.Fatal(err)
+				}
+				point := origin.Add(direction.Mul(4))
+				screen, visible, err := camera.EyeWorldToScreen(eye, FullSideBySide, point)
+				if err != nil {
+					t.Fatal(err)
+				}
+				//The right eye's viewport is the right half of the output, so the pixel is outside it
+				if visible != (eye != RightEye) {
+					t.Errorf("expected %v to be visible only outside the right eye, got %v", point, visible)
+				}
+				assertVec2(t, screen, pixel)
+			})
+		}
+	}
+}
+
+func TestEyeScreenMatchesStereoViews(t *testing.T) {
+	for _, layout := range []StereoLayout{HalfSideBySide, FullSideBySide, TopBottom, Anaglyph} {
+		camera := New(FPS)
+		camera.SetIPD(0.5)
+		camera.SetPosition(3, 2, 7)
+		camera.LookAt(0, 0, 0)
+		views, err := camera.StereoViews(layout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, view := range views {
+			t.Run(layout.String()+"-"+view.Eye.String(), func(t *testing.T) {
+				//The corners and middle of the eye's viewport, measured from the top left of the output
+				viewport := view.Viewport
+				left, right := float32(viewport.X), float32(viewport.X+viewport.Width)
+				top, bottom := camera.Screenheight-float32(viewport.Y+viewport.Height), camera.Screenheight-float32(viewport.Y)
+				transform := view.Projection.Mul4(view.View)
+				for _, pixel := range []mgl32.Vec2{{left, top}, {right, top}, {left, bottom}, {right, bottom}, {(left + right) / 2, (top + bottom) / 2}, {left + 100, top + 200}} {
+					origin, direction, err := camera.EyeScreenRay(view.Eye, layout, pixel.X(), pixel.Y())
+					if err != nil {
+						t.Fatal(err)
+					}
+					point := origin.Add(direction.Mul(4))
+
+					//Where the layout draws the point, as gl.Viewport maps it, measured from the top left of the output
+					ndc := mgl32.TransformCoordinate(point, transform)
+					drawn := mgl32.Vec2{
+						float32(viewport.X) + (ndc.X()+1)/2*float32(viewport.Width),
+						camera.Screenheight - float32(viewport.Y) - (ndc.Y()+1)/2*float32(viewport.Height),
+					}
+					screen, _, err := camera.EyeWorldToScreen(view.Eye, layout, point)
+					if err != nil {
+						t.Fatal(err)
+					}
+					//Compare in pixels, since the corners are near zero, to a twentieth of a pixel
+					if drawn.Sub(pixel).Len() > 5e-2 || screen.Sub(pixel).Len() > 5e-2 {
+						t.Errorf("expected pixel %v to be drawn and reported there, got %v and %v", pixel, drawn, screen)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestTopBottomPicking(t *testing.T) {
+	camera := New(FPS)
+	camera.SetIPD(0.5)
+	//The middle of the top half of the output is the middle of the left eye's view, and the bottom half is the right eye's
+	for _, pick := range []struct {
+		eye   Eye
+		pixel mgl32.Vec2
+	}{{LeftEye, mgl32.Vec2{960, 270}}, {RightEye, mgl32.Vec2{960, 810}}} {
+		origin, direction, err := camera.EyeScreenRay(pick.eye, TopBottom, pick.pixel.X(), pick.pixel.Y())
+		if err != nil {
+			t.Fatal(err)
+		}
+		//Off-axis eyes converge at the focal length, straight ahead of the camera
+		focus := camera.Position.Add(camera.ForwardsVector().Mul(camera.FocalLength))
+		if distance := focus.Sub(origin).Cross(direction).Len(); distance > 1e-3 {
+			t.Errorf("expected the %v eye's ray through %v to pass through %v, missed by %v", pick.eye, pick.pixel, focus, distance)
+		}
+		screen, visible, err := camera.EyeWorldToScreen(pick.eye, TopBottom, focus)
+		if err != nil || !visible {
+			t.Fatalf("expected %v to be visible, got %v %v", focus, visible, err)
+		}
+		assertVec2(t, screen, pick.pixel)
+	}
+
+	if _, _, err := camera.EyeScreenRay(LeftEye, StereoLayout(42), 0, 0); !errors.Is(err, ErrUnknownStereoLayout) {
+		t.Errorf("expected ErrUnknownStereoLayout, got %v", err)
+	}
+}
+
+func TestWorldToScreen(t *testing.T) {
+	camera := New(FPS)
+	screen, visible := camera.WorldToScreen(mgl32.Vec3{0, 0, 0})
+	if !visible {
+		t.Error("expected the target to be visible")
+	}
+	assertVec2(t, screen, mgl32.Vec2{960, 540})
+
+	//Up the screen is towards the top, which is a smaller pixel row
+	screen, _ = camera.WorldToScreen(mgl32.Vec3{0, 1, 0})
+	if screen.Y() >= 540 {
+		t.Errorf("expected a point above the target to be higher on screen, got %v", screen)
+	}
+
+	if _, visible := camera.WorldToScreen(mgl32.Vec3{0, 0, 10}); visible {
+		t.Error("expected a point behind the camera to be hidden")
+	}
+	if _, visible := camera.WorldToScreen(mgl32.Vec3{0, 0, -100}); visible {
+		t.Error("expected a point beyond the far plane to be hidden")
+	}
+	screen, visible = camera.WorldToScreen(mgl32.Vec3{50, 0, 0})
+	if visible || screen.X() <= camera.Screenwidth {
+		t.Errorf("expected a point off the right edge to be hidden, with a large x, got %v %v", screen, visible)
+	}
+}
+
+func TestEyePickingErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		eye      Eye
+		change   func(*Camera)
+		expected []string
+	}{
+		{"mono minimised", MonoEye, func(c *Camera) { c.Screenwidth, c.Screenheight = 0, 0 }, []string{"Screenwidth", "Screenheight"}},
+		{"left without IPD", LeftEye, func(c *Camera) { c.SetIPD(0) }, []string{"IPD"}},
+		{"right without FocalLength", RightEye, func(c *Camera) { c.SetFocalLength(0) }, []string{"FocalLength"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			camera := New(FPS)
+			tt.change(camera)
+			_, _, err := camera.EyeScreenRay(tt.eye, FullSideBySide, 10, 10)
+			if fields := invalidFields(t, err); !reflect.DeepEqual(fields, tt.expected) {
+				t.Errorf("expected EyeScreenRay to report %v, got %v", tt.expected, fields)
+			}
+			_, visible, err := camera.EyeWorldToScreen(tt.eye, FullSideBySide, mgl32.Vec3{})
+			if fields := invalidFields(t, err); visible || !reflect.DeepEqual(fields, tt.expected) {
+				t.Errorf("expected EyeWorldToScreen to report %v and no visible point, got %v %v", tt.expected, fields, visible)
+			}
+		})
+	}
+}
+
+func TestEyeString(t *testing.T) {
+	if LeftEye.String() != "Left" || Eye(5).String() != "Eye(5)" {
+		t.Errorf("unexpected eye names %q %q", LeftEye.String(), Eye(5).String())
+	}
+}
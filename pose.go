@@ -0,0 +1,44 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Pose is where a camera is, where it is looking, and how wide its view is.
+type Pose struct {
+	Position mgl32.Vec3 //The position of the camera in world space
+	Target   mgl32.Vec3 //The point the camera looks at, in world space
+	Up       mgl32.Vec3 //The up vector of the camera
+	FOV      float32    //The field of view of the camera, in radians
+}
+
+// Pose returns the camera's current pose.
+func (c *Camera) Pose() Pose {
+	return Pose{Position: c.Position, Target: c.Target, Up: c.Up, FOV: c.FOV}
+}
+
+// SetPose moves the camera to a pose, and points it at the pose's target.
+func (c *Camera) SetPose(pose Pose) {
+	c.Position = pose.Position
+	c.Up = pose.Up
+	c.FOV = pose.FOV
+	c.LookAt(pose.Target.X(), pose.Target.Y(), pose.Target.Z())
+}
+
+// Orientation returns the view rotation for the pose, in the same form as Camera.Orientation.
+func (p Pose) Orientation() mgl32.Quat {
+	return mgl32.Mat4ToQuat(mgl32.LookAtV(p.Position, p.Target, p.Up))
+}
+
+// poseFromOrientation builds a pose that looks along a view rotation, with the target the given distance away.
+// The up vector is the camera's own up vector, so a rolled orientation is kept.
+func poseFromOrientation(position mgl32.Vec3, orientation mgl32.Quat, targetDistance, fov float32) Pose {
+	//The orientation rotates world space into camera space, so its inverse rotates the camera axes into world space
+	toWorld := orientation.Conjugate()
+	forward := toWorld.Rotate(mgl32.Vec3{0, 0, -1})
+	up := toWorld.Rotate(mgl32.Vec3{0, 1, 0})
+	return Pose{
+		Position: position,
+		Target:   position.Add(forward.Mul(targetDistance)),
+		Up:       up,
+		FOV:      fov,
+	}
+}
@@ -0,0 +1,136 @@
+package sceneCamera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// ProjectionKind selects the projection matrix returned by ProjectionMatrix.
+type ProjectionKind int
+
+// Projection kinds.  The zero value is a standard perspective projection.
+const (
+	// Perspective is the standard OpenGL perspective projection, mapping Near to -1 and Far to 1.
+	Perspective ProjectionKind = iota
+	// Orthographic is a parallel projection OrthoHeight world units high, with the width taken from the screen aspect ratio.
+	Orthographic
+	// ReversedZ is a perspective projection mapping Near to 1 and Far to 0.
+	// Use it with glClipControl(GL_LOWER_LEFT, GL_ZERO_TO_ONE), a depth test of GL_GREATER, and a depth buffer cleared to 0.
+	ReversedZ
+	// InfiniteFar is a perspective projection with the far plane at infinity.  Far is ignored.
+	InfiniteFar
+	// ReversedZInfiniteFar combines ReversedZ and InfiniteFar.  Far is ignored, and depth reaches 0 only at infinity.
+	ReversedZInfiniteFar
+)
+
+// String returns the name of the projection kind.
+func (p ProjectionKind) String() string {
+	switch p {
+	case Perspective:
+		return "Perspective"
+	case Orthographic:
+		return "Orthographic"
+	case ReversedZ:
+		return "ReversedZ"
+	case InfiniteFar:
+		return "InfiniteFar"
+	case ReversedZInfiniteFar:
+		return "ReversedZInfiniteFar"
+	}
+	return fmt.Sprintf("ProjectionKind(%d)", int(p))
+}
+
+// reversedZ reports whether the projection maps the near plane to depth 1 and the far plane to depth 0.
+func (p ProjectionKind) reversedZ() bool {
+	return p == ReversedZ || p == ReversedZInfiniteFar
+}
+
+// SetProjection chooses the kind of matrix returned by ProjectionMatrix.
+func (c *Camera) SetProjection(kind ProjectionKind) {
+	c.Projection = kind
+}
+
+// SetOrthoHeight sets the height of the orthographic view volume, in world units.
+func (c *Camera) SetOrthoHeight(height float32) {
+	c.OrthoHeight = height
+}
+
+// AspectRatio returns the width of the screen divided by its height.
+func (c *Camera) AspectRatio() float32 {
+	return c.Screenwidth / c.Screenheight
+}
+
+// ProjectionMatrix returns the projection matrix for the camera, according to c.Projection.  It can be passed directly to OpenGL as the ProjectionMatrix.
+func (c *Camera) ProjectionMatrix() mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.projectionCache(cache, MonoEye).projection
+}
+
+// monoProjection computes the matrix returned by ProjectionMatrix.
+func (c *Camera) monoProjection() mgl32.Mat4 {
+	aspect := c.AspectRatio()
+	switch c.Projection {
+	case Orthographic:
+		top := c.OrthoHeight / 2
+		right := top * aspect
+		return mgl32.Ortho(-right, right, -top, top, c.Near, c.Far)
+	case ReversedZ:
+		return reversedZPerspective(c.FOV, aspect, c.Near, c.Far)
+	case InfiniteFar:
+		return infinitePerspective(c.FOV, aspect, c.Near)
+	case ReversedZInfiniteFar:
+		return reversedZInfinitePerspective(c.FOV, aspect, c.Near)
+	}
+	return mgl32.Perspective(c.FOV, aspect, c.Near, c.Far)
+}
+
+// focalScale is the cotangent of half the field of view, the scale applied to x and y by a perspective projection.
+func focalScale(fovy float32) float32 {
+	return float32(1 / math.Tan(float64(fovy)/2))
+}
+
+// infinitePerspective is mgl32.Perspective with the far plane taken to infinity.
+func infinitePerspective(fovy, aspect, near float32) mgl32.Mat4 {
+	f := focalScale(fovy)
+	return mgl32.Mat4{
+		f / aspect, 0, 0, 0,
+		0, f, 0, 0,
+		0, 0, -1, -1,
+		0, 0, -2 * near, 0,
+	}
+}
+
+// reversedZPerspective maps view depth near to 1 and far to 0, for a zero-to-one depth range.
+func reversedZPerspective(fovy, aspect, near, far float32) mgl32.Mat4 {
+	f := focalScale(fovy)
+	depth := far - near
+	return mgl32.Mat4{
+		f / aspect, 0, 0, 0,
+		0, f, 0, 0,
+		0, 0, near / depth, -1,
+		0, 0, near * far / depth, 0,
+	}
+}
+
+// reversedZInfinitePerspective is reversedZPerspective with the far plane taken to infinity.
+func reversedZInfinitePerspective(fovy, aspect, near float32) mgl32.Mat4 {
+	f := focalScale(fovy)
+	return mgl32.Mat4{
+		f / aspect, 0, 0, 0,
+		0, f, 0, 0,
+		0, 0, 0, -1,
+		0, 0, near, 0,
+	}
+}
+
+// scaleOrthoHeight keeps the orthographic view in step with a zoom that moves the camera from one distance to another.
+// A perspective camera zooms by moving, but an orthographic one only zooms when its view volume changes size.
+func (c *Camera) scaleOrthoHeight(before, after float32) {
+	if before <= 0 || after <= 0 {
+		return
+	}
+	c.OrthoHeight *= after / before
+}
@@ -0,0 +1,91 @@
+package sceneCamera
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// projectDepth returns the normalised device depth of a point straight ahead of the camera, at the given view distance.
+func projectDepth(projection mgl32.Mat4, distance float32) float32 {
+	clip := projection.Mul4x1(mgl32.Vec4{0, 0, -distance, 1})
+	return clip.Z() / clip.W()
+}
+
+func TestProjectionKindString(t *testing.T) {
+	if Orthographic.String() != "Orthographic" {
+		t.Errorf("expected Orthographic, got %q", Orthographic.String())
+	}
+	if ProjectionKind(9).String() != "ProjectionKind(9)" {
+		t.Errorf("expected ProjectionKind(9), got %q", ProjectionKind(9).String())
+	}
+}
+
+func TestPerspectiveProjection(t *testing.T) {
+	camera := New(FPS)
+	expected := mgl32.Perspective(camera.FOV, camera.Screenwidth/camera.Screenheight, camera.Near, camera.Far)
+	assertMat4(t, camera.ProjectionMatrix(), expected)
+}
+
+func TestOrthographicProjection(t *testing.T) {
+	camera := New(Museum)
+	camera.SetProjection(Orthographic)
+	camera.SetOrthoHeight(4)
+	projection := camera.ProjectionMatrix()
+
+	edge := projection.Mul4x1(mgl32.Vec4{2 * camera.AspectRatio(), 2, -3, 1})
+	assertFloat(t, edge.X()/edge.W(), 1)
+	assertFloat(t, edge.Y()/edge.W(), 1)
+	assertFloat(t, projectDepth(projection, camera.Near), -1)
+	assertFloat(t, projectDepth(projection, camera.Far), 1)
+}
+
+func TestDepthMappings(t *testing.T) {
+	testCases := []struct {
+		name  string
+		kind  ProjectionKind
+		near  float32
+		far   float32
+		large float32
+	}{
+		{name: "perspective", kind: Perspective, near: -1, far: 1},
+		{name: "reversed-z", kind: ReversedZ, near: 1, far: 0},
+		{name: "infinite-far", kind: InfiniteFar, near: -1, large: 1},
+		{name: "reversed-z-infinite-far", kind: ReversedZInfiniteFar, near: 1, large: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			camera := New(FPS)
+			camera.SetProjection(testCase.kind)
+			projection := camera.ProjectionMatrix()
+			assertFiniteMat4(t, projection)
+			assertFloat(t, projectDepth(projection, camera.Near), testCase.near)
+			if testCase.kind == InfiniteFar || testCase.kind == ReversedZInfiniteFar {
+				assertFloat(t, projectDepth(projection, 1e6), testCase.large)
+			} else {
+				assertFloat(t, projectDepth(projection, camera.Far), testCase.far)
+			}
+
+			perspective := mgl32.Perspective(camera.FOV, camera.AspectRatio(), camera.Near, camera.Far)
+			assertFloat(t, projection[0], perspective[0])
+			assertFloat(t, projection[5], perspective[5])
+		})
+	}
+}
+
+func TestZoomScalesOrthoHeight(t *testing.T) {
+	camera := New(Museum)
+	camera.SetProjection(Orthographic)
+	camera.Move(Forward, 2.5)
+	assertFloat(t, camera.OrthoHeight, 5)
+	camera.Move(Backward, 2.5)
+	assertFloat(t, camera.OrthoHeight, 10)
+
+	camera = New(RTS)
+	original := camera.OrthoHeight
+	camera.Move(Up, 1)
+	if camera.OrthoHeight >= original {
+		t.Errorf("expected RTS zoom in to shrink the ortho height below %v, got %v", original, camera.OrthoHeight)
+	}
+}
@@ -10,31 +10,59 @@ import (
 
 // Camera holds the position, orientation, projection settings, and movement mode of a 3D camera.
 type Camera struct {
-	Position          mgl32.Vec3 //The position of the camera in world space
-	Target            mgl32.Vec3 //The target of the camera in world space.  Note: not the focal point
-	Up                mgl32.Vec3 //The up vector of the camera
-	Orientation       mgl32.Quat //The orientation of the camera, quaternion
-	Mode              int        //The mode of the camera.  1 - Museum mode, 2 - FPS mode, 3 - RTS mode
-	GroundPlaneNormal mgl32.Vec3 //The normal of the ground plane
-	IPD               float32    //The inter-pupillary distance, in world space
-	FocalLength       float32    //The focal length of the camera, in world space
-	Near              float32    //The near clipping plane
-	Far               float32    //The far clipping plane
-	Screenheight      float32    //The height of the screen, in pixels
-	Screenwidth       float32    //The width of the screen, in pixels
-	Aperture          float32    //The aperture of the camera, in world space
-	FOV               float32    //The field of view of the camera, in radians
-
+	Position          mgl32.Vec3     //The position of the camera in world space
+	Target            mgl32.Vec3     //The target of the camera in world space.  Note: not the focal point
+	Up                mgl32.Vec3     //The up vector of the camera
+	Orientation       mgl32.Quat     //The orientation of the camera, quaternion
+	Mode              Mode           //The mode of the camera: Museum, FPS, RTS, Flight or Follow
+	GroundPlaneNormal mgl32.Vec3     //The normal of the ground plane
+	IPD               float32        //The inter-pupillary distance, in world space
+	FocalLength       float32        //The focal length of the camera, in world space
+	Near              float32        //The near clipping plane
+	Far               float32        //The far clipping plane
+	Screenheight      float32        //The height of the screen, in pixels
+	Screenwidth       float32        //The width of the screen, in pixels
+	Aperture          float32        //The aperture of the camera, in world space
+	FOV               float32        //The field of view of the camera, in radians
+	Projection        ProjectionKind //The kind of matrix returned by ProjectionMatrix
+	OrthoHeight       float32        //The height of the orthographic view volume, in world space.  Museum and RTS zoom scale it
+	MinZoomDistance   float32        //The closest that ZoomToCursor will move to the point under the cursor, and that museum and RTS zoom will move to the target
+	MaxZoomDistance   float32        //The furthest that ZoomToCursor will move from the point under the cursor.  Zero for no limit
+	Acceleration      float32        //How quickly Update changes the velocity, in units per second per second.  Zero for instant changes
+	TurnAcceleration  float32        //How quickly Update changes the pitch, yaw and roll rates, in radians per second per second.  Zero for instant changes
+	MaxSpeed          float32        //The fastest that Update moves the camera, per second.  Zero for no limit
+	MaxTurnRate       float32        //The fastest that Update turns the camera, in radians per second.  Zero for no limit
+	Damping           float32        //The rate at which Update slows the camera once input stops, per second.  Zero to stop immediately
+	AutoLevelRate     float32        //How quickly Update rolls a Flight mode camera back to level, in radians per second.  Zero to disable
+	FollowOffset      FollowOffset   //Where a Follow mode camera sits, relative to its subject
+	FollowLag         float32        //How far a Follow mode camera lags behind its subject, in seconds.  Zero for no lag
+	FollowLookLag     float32        //How far a Follow mode camera's view direction lags behind, in seconds.  Zero for no lag
+	FollowLookAhead   float32        //How far ahead a Follow mode camera looks, in seconds of subject velocity
+	Orbit             OrbitStyle     //How museum mode orbits the target: WorldAxes, Turntable or Trackball
+	OrbitPitchLimit   float32        //How far above or below the horizon a Turntable orbit can go, in radians.  Zero for as far as possible
+	Stereo            StereoModel    //How the eye views are built: OffAxis, ToeIn or ParallelAxis
+
+	dragAnchor       mgl32.Vec3   //The ground point held under the cursor by DragGround
+	dragging         bool         //True between BeginGroundDrag and EndGroundDrag
+	velocity         [6]float32   //The current velocity along each axis, for Update
+	desiredVelocity  [6]float32   //The velocity along each axis that Update accelerates towards
+	transition       transition   //The FlyTo in progress, if any
+	controller       Controller   //The controller for controllerMode
+	controllerMode   Mode         //The mode that controller was created for.  When Mode no longer matches, the controller is replaced
+	controllerCamera *Camera      //The camera that controller was created for.  A copy of the camera gets its own controller
+	cache            *matrixCache //The matrices and basis vectors, and the fields they were computed from.  Shared by value copies, but locked, so that is safe
 }
 
 // PI is a single-precision approximation of pi retained for compatibility.
 var PI = float32(3.1415927)
 
 // New creates a camera in the selected movement mode.
-// 1 - Museum mode
-// 2 - FPS mode
-// 3 - RTS mode
-func New(mode int) *Camera {
+// Museum (1) - Museum mode
+// FPS (2) - FPS mode
+// RTS (3) - RTS mode
+// Flight (4) - Flight mode
+// Follow (5) - Follow mode
+func New(mode Mode) *Camera {
 
 	c := &Camera{
 		Position:          mgl32.Vec3{0.0, 0.0, 5.0},
@@ -50,8 +78,21 @@ func New(mode int) *Camera {
 		IPD:               2.0,
 		Screenheight:      1080.0,
 		Screenwidth:       1920.0,
+		OrthoHeight:       10.0,
+		MinZoomDistance:   1.0,
+		Acceleration:      20.0,
+		TurnAcceleration:  10.0,
+		MaxSpeed:          10.0,
+		MaxTurnRate:       3.0,
+		Damping:           8.0,
+		FollowOffset:      FollowOffset{Distance: 6.0, Height: 2.0},
+		FollowLag:         0.3,
+		FollowLookLag:     0.15,
+		FollowLookAhead:   0.5,
+		OrbitPitchLimit:   1.5,
+		cache:             &matrixCache{},
 	}
-	if mode == 3 {
+	if mode == RTS {
 		c.Up = c.GroundPlaneNormal
 		c.Position = mgl32.Vec3{5.0, 5.0, 5.0}
 		//In RTS mode, set the initial target to a point on the ground plane
@@ -60,20 +101,44 @@ func New(mode int) *Camera {
 
 	}
 	c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
+	c.activeController()
 	return c
 }
 
+// Clone returns a copy of the camera, with its own controller and matrix cache.
+// Copying a Camera by value works too, but Clone makes it plain that nothing is shared: moving or updating the copy never moves the original.
+// The copy's controller starts afresh, so a Follow mode copy waits for its own SetFollowSubject.
+func (c *Camera) Clone() *Camera {
+	clone := *c
+	clone.cache = &matrixCache{}
+	return &clone
+}
+
 // SetUp sets the camera's up vector.
 func (c *Camera) SetUp(x, y, z float32) {
 	c.Up = mgl32.Vec3{x, y, z}
 }
 
 // Choose the mode of the camera.
-// 1 - Museum mode
-// 2 - FPS mode
-// 3 - RTS mode
-func (c *Camera) SetMode(mode int) {
+// Museum (1) - Museum mode
+// FPS (2) - FPS mode
+// RTS (3) - RTS mode
+// Flight (4) - Flight mode
+// Follow (5) - Follow mode
+//
+// The previous mode's controller is exited, and the new mode's controller is entered.  Setting the same mode again does nothing.
+func (c *Camera) SetMode(mode Mode) {
+	previous := c.activeController()
+	if mode == c.Mode {
+		return
+	}
+	if previous != nil {
+		previous.Exit(c)
+	}
 	c.Mode = mode
+	if controller := c.activeController(); controller != nil {
+		controller.Enter(c)
+	}
 }
 
 // Set the normal of the ground plane.  This is used in RTS mode, and ignored in other modes.
@@ -93,9 +158,35 @@ func (c *Camera) Dump() {
 }
 
 // One of the more important functions, LookAt sets the target of the camera.
+//
+// A target closer than MinTargetDistance has no direction, so the camera keeps its view direction and the target is pushed out to MinTargetDistance.
+// If Up is parallel to the view direction, the camera's current up vector is used instead, so the camera does not spin or flip.
 func (c *Camera) LookAt(x, y, z float32) {
-	c.Target = mgl32.Vec3{x, y, z}
-	c.Orientation = mgl32.Mat4ToQuat(mgl32.LookAtV(c.Position, c.Target, c.Up))
+	target := mgl32.Vec3{x, y, z}
+	toTarget := target.Sub(c.Position)
+	if toTarget.Len() < MinTargetDistance {
+		forward, _, _ := c.cameraAxes()
+		c.Target = c.Position.Add(forward.Mul(MinTargetDistance))
+		return
+	}
+	c.Target = target
+	c.Orientation = mgl32.Mat4ToQuat(mgl32.LookAtV(c.Position, c.Target, c.lookUp(toTarget.Normalize())))
+}
+
+// lookUp returns the up vector to build a view looking along forward: Up, unless it is parallel to forward, or zero.
+// Then it is the camera's current up vector, or failing that, any direction at right angles to forward.
+func (c *Camera) lookUp(forward mgl32.Vec3) mgl32.Vec3 {
+	if c.Up.Len() > 0 && forward.Cross(c.Up.Normalize()).Len() > parallelEpsilon {
+		return c.Up
+	}
+	_, _, up := c.cameraAxes()
+	if forward.Cross(up).Len() > parallelEpsilon {
+		return up
+	}
+	if math.Abs(float64(forward.X())) < 0.9 {
+		return forward.Cross(mgl32.Vec3{1, 0, 0})
+	}
+	return forward.Cross(mgl32.Vec3{0, 1, 0})
 }
 
 // Returns the position of the camera in world space
@@ -131,59 +222,31 @@ func (c *Camera) SetFocalLength(focalLength float32) {
 
 // Return the ViewMatrix for the camera.  This is the matrix that transforms world space to camera space.  It contains both the rotation and translation of the camera.  It can be passed directly to OpenGL as the ViewMatrix, and used in GLSL shaders as the ViewMatrix.
 func (c *Camera) ViewMatrix() mgl32.Mat4 {
-	rotation := c.Orientation.Mat4()
-	translation := mgl32.Translate3D(-c.Position.X(), -c.Position.Y(), -c.Position.Z())
-	return rotation.Mul4(translation)
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).views[MonoEye]
 }
 
 // LeftEyeViewMatrix returns the view matrix for the left eye.
 func (c *Camera) LeftEyeViewMatrix() mgl32.Mat4 {
-
-	rightVec := c.RightWardsVector()
-	eyepos := c.Position.Sub(rightVec.Mul(c.IPD / 2))
-	rotation := c.Orientation.Mat4()
-
-	translation := mgl32.Translate3D(-eyepos.X(), -eyepos.Y(), -eyepos.Z())
-	return rotation.Mul4(translation)
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).views[LeftEye]
 }
 
 // RightEyeViewMatrix returns the view matrix for the right eye.
 func (c *Camera) RightEyeViewMatrix() mgl32.Mat4 {
-
-	rightVec := c.RightWardsVector()
-	eyepos := c.Position.Add(rightVec.Mul(c.IPD / 2))
-	rotation := c.Orientation.Mat4()
-	translation := mgl32.Translate3D(-eyepos.X(), -eyepos.Y(), -eyepos.Z())
-	return rotation.Mul4(translation)
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).views[RightEye]
 }
 
-// RightEyeFrustum returns the frustum matrix for the right eye.
+// RightEyeFrustum returns the frustum matrix for the right eye.  See StereoModel for how it is shifted.
+// It panics if Screenheight, Screenwidth, IPD, Near, Far or FOV is zero, or FocalLength is zero with OffAxis or ToeIn; RightEyeFrustumChecked returns an error instead.
 func (c *Camera) RightEyeFrustum() mgl32.Mat4 {
-	if c.Screenheight == 0 {
-		panic("Screen height is zero")
-	}
-	if c.Screenwidth == 0 {
-		panic("Screen width is zero")
-	}
-	if c.IPD == 0 {
-		panic("IPD is zero")
-	}
-	if c.Near == 0 {
-		panic("Near is zero")
-	}
-	if c.Far == 0 {
-		panic("Far is zero")
-	}
-	if c.FOV == 0 {
-		panic("FOV is zero")
-	}
-	aspect_ratio := c.Screenwidth / c.Screenheight / 2
-	frustumshift := (c.IPD / 2) * c.Near / c.Far
-	top := c.Near * float32(math.Tan(float64(c.FOV/2)))
-	right := aspect_ratio*top + frustumshift
-	left := -aspect_ratio*top + frustumshift
-	bottom := -top
-	return mgl32.Frustum(left, right, bottom, top, c.Near, c.Far)
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.projectionCache(cache, RightEye).projection
 }
 
 // RightEyeFrustrum returns the frustum matrix for the right eye.
@@ -192,8 +255,22 @@ func (c *Camera) RightEyeFrustrum() mgl32.Mat4 {
 	return c.RightEyeFrustum()
 }
 
-// LeftEyeFrustum returns the frustum matrix for the left eye.
+// LeftEyeFrustum returns the frustum matrix for the left eye.  See StereoModel for how it is shifted.
+// It panics if Screenheight, Screenwidth, IPD, Near, Far or FOV is zero, or FocalLength is zero with OffAxis or ToeIn; LeftEyeFrustumChecked returns an error instead.
 func (c *Camera) LeftEyeFrustum() mgl32.Mat4 {
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.projectionCache(cache, LeftEye).projection
+}
+
+// LeftEyeFrustrum returns the frustum matrix for the left eye.
+// Deprecated: use LeftEyeFrustum.
+func (c *Camera) LeftEyeFrustrum() mgl32.Mat4 {
+	return c.LeftEyeFrustum()
+}
+
+// checkEyeFrustum panics if the fields that the eye frustums use cannot make a frustum.
+func (c *Camera) checkEyeFrustum() {
 	if c.Screenheight == 0 {
 		panic("Screen height is zero")
 	}
@@ -212,19 +289,9 @@ func (c *Camera) LeftEyeFrustum() mgl32.Mat4 {
 	if c.FOV == 0 {
 		panic("FOV is zero")
 	}
-	aspect_ratio := c.Screenwidth / c.Screenheight / 2
-	frustumshift := (c.IPD / 2) * c.Near / c.Far
-	top := c.Near * float32(math.Tan(float64(c.FOV/2)))
-	right := aspect_ratio*top - frustumshift
-	left := -aspect_ratio*top - frustumshift
-	bottom := -top
-	return mgl32.Frustum(left, right, bottom, top, c.Near, c.Far)
-}
-
-// LeftEyeFrustrum returns the frustum matrix for the left eye.
-// Deprecated: use LeftEyeFrustum.
-func (c *Camera) LeftEyeFrustrum() mgl32.Mat4 {
-	return c.LeftEyeFrustum()
+	if c.FocalLength == 0 && c.Stereo != ParallelAxis {
+		panic("FocalLength is zero")
+	}
 }
 
 // Reset the camera to its initial position
@@ -237,28 +304,24 @@ func (c *Camera) Reset() {
 }
 
 // Move the camera, according to the parameter
-// 0 - forward
-// 1 - backward
-// 2 - left
-// 3 - right
-// 4 - up
-// 5 - down
-// 6 - pitch up
-// 7 - pitch down
-// 8 - yaw left
-// 9 - yaw right
-// 10 - roll left
-// 11 - roll right
-func (c *Camera) Move(direction int, amount float32) {
-	switch c.Mode {
-	case 1:
-		c.moveMuseumMode(direction, amount)
-	case 2:
-		c.moveFPSMode(direction, amount)
-	case 3:
-		c.moveRTSMode(direction, amount)
+// Forward (0) - forward
+// Backward (1) - backward
+// Left (2) - left
+// Right (3) - right
+// Up (4) - up
+// Down (5) - down
+// PitchUp (6) - pitch up
+// PitchDown (7) - pitch down
+// YawLeft (8) - yaw left
+// YawRight (9) - yaw right
+// RollLeft (10) - roll left
+// RollRight (11) - roll right
+//
+// The current mode's Controller does the moving.  Directions that it does not support are ignored.  Use MoveChecked to detect them.
+func (c *Camera) Move(direction Direction, amount float32) {
+	if controller := c.activeController(); controller != nil {
+		controller.Move(c, direction, amount)
 	}
-
 }
 
 // Move the camera through world space
@@ -274,71 +337,88 @@ func (c *Camera) Rotate(x, y, z float32) {
 	c.Orientation = c.Orientation.Mul(quatX).Mul(quatY).Mul(quatZ)
 }
 
-func (c *Camera) moveMuseumMode(direction int, amount float32) {
+// museumController orbits the camera around the target, and zooms in or out.
+type museumController struct{}
+
+func (museumController) Update(c *Camera, dt float32) {}
+
+func (museumController) Supports(direction Direction) bool {
+	return direction >= Forward && direction <= Down
+}
+
+func (museumController) Move(c *Camera, direction Direction, amount float32) {
 	forward := c.ForwardsVector()
 	relativePosition := c.Position.Sub(c.Target)
 
 	switch direction {
-	case 0: // Zoom in
+	case Forward: // Zoom in
+		//Stop at MinZoomDistance, rather than passing through the target and turning around
+		amount = min(amount, max(relativePosition.Len()-max(c.MinZoomDistance, MinTargetDistance), 0))
 		c.Position = c.Position.Add(forward.Mul(amount))
 		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 1: // Zoom out
+		c.scaleOrthoHeight(relativePosition.Len(), c.Position.Sub(c.Target).Len())
+	case Backward: // Zoom out
 		c.Position = c.Position.Sub(forward.Mul(amount))
 		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 2: // Orbit left
-		//Rotate the camera around the target by the specified amount
-
-		new_relative_position := mgl32.HomogRotate3DY(amount).Mul4x1(relativePosition.Vec4(0))
-		c.Position = c.Target.Add(new_relative_position.Vec3())
-		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 3: // Orbit right
-		//Rotate the camera around the target by the specified amount
-
-		new_relative_position := mgl32.HomogRotate3DY(-amount).Mul4x1(relativePosition.Vec4(0))
-		c.Position = c.Target.Add(new_relative_position.Vec3())
-		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 4: //Orbit up
-
-		new_relative_position := mgl32.HomogRotate3DX(-amount).Mul4x1(relativePosition.Vec4(0))
-		c.Position = c.Target.Add(new_relative_position.Vec3())
-		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 5: // Orbit down
-
-		new_relative_position := mgl32.HomogRotate3DX(amount).Mul4x1(relativePosition.Vec4(0))
-		c.Position = c.Target.Add(new_relative_position.Vec3())
-		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-
-	case 6: // Pitch up (Not applicable in museum mode)
-	case 7: // Pitch down (Not applicable in museum mode)
-	case 8: // Yaw left (Not applicable in museum mode)
-	case 9: // Yaw right (Not applicable in museum mode)
-	case 10: // Roll left (Not applicable in museum mode)
-	case 11: // Roll right (Not applicable in museum mode)
+		c.scaleOrthoHeight(relativePosition.Len(), c.Position.Sub(c.Target).Len())
+	case Left: // Orbit left
+		//Rotate the camera around the target by the specified amount, in the camera's orbit style
+		c.orbitMuseum(amount, 0)
+	case Right: // Orbit right
+		c.orbitMuseum(-amount, 0)
+	case Up: //Orbit up
+		c.orbitMuseum(0, amount)
+	case Down: // Orbit down
+		c.orbitMuseum(0, -amount)
+
+	case PitchUp: // Pitch up (Not applicable in museum mode)
+	case PitchDown: // Pitch down (Not applicable in museum mode)
+	case YawLeft: // Yaw left (Not applicable in museum mode)
+	case YawRight: // Yaw right (Not applicable in museum mode)
+	case RollLeft: // Roll left (Not applicable in museum mode)
+	case RollRight: // Roll right (Not applicable in museum mode)
 	}
 }
 
-// The forward unit vector of the camera, in world space
+// The forward unit vector of the camera, in world space.
+// If the target is closer than MinTargetDistance, it is taken from Orientation instead.
 func (c *Camera) ForwardsVector() mgl32.Vec3 {
-	toTarget := c.Target.Sub(c.Position)
-	forward := toTarget.Normalize()
-	return forward
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).forward
 }
 
-// The right unit vector of the camera, in world space
+// The right unit vector of the camera, in world space.
+// If Up is parallel to the view direction, it is taken from Orientation instead.
 func (c *Camera) RightWardsVector() mgl32.Vec3 {
-	toTarget := c.Target.Sub(c.Position).Normalize()
-	forward := toTarget
-	right := forward.Cross(c.Up).Normalize()
-	return right
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).right
 }
 
 // The up unit vector of the camera, in world space
 func (c *Camera) UpwardsVector() mgl32.Vec3 {
-	toTarget := c.Target.Sub(c.Position).Normalize()
-	forward := toTarget
-	right := forward.Cross(c.Up).Normalize()
-	up := right.Cross(forward).Normalize()
-	return up
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.viewCache(cache).up
+}
+
+// basis computes the forward, right and up unit vectors, for ForwardsVector, RightWardsVector and UpwardsVector.
+func (c *Camera) basis() (forward, right, up mgl32.Vec3) {
+	toTarget := c.Target.Sub(c.Position)
+	if toTarget.Len() < MinTargetDistance {
+		forward, _, _ = c.cameraAxes()
+	} else {
+		forward = toTarget.Normalize()
+	}
+	right = forward.Cross(c.Up)
+	if right.Len() < parallelEpsilon {
+		_, right, _ = c.cameraAxes()
+	} else {
+		right = right.Normalize()
+	}
+	up = right.Cross(forward).Normalize()
+	return forward, right, up
 }
 
 // Scenecam keeps an invisible target point to which the camera is always looking.  Not normalised.  This is the vector from the camera to the target.
@@ -354,51 +434,67 @@ func (c *Camera) TargetPosition() mgl32.Vec3 {
 	return c.Target
 }
 
-func (c *Camera) moveFPSMode(direction int, amount float32) {
+// fpsController translates, pitches and yaws the camera like a first-person camera.
+type fpsController struct{}
+
+func (fpsController) Update(c *Camera, dt float32) {}
+
+func (fpsController) Supports(direction Direction) bool {
+	return direction >= Forward && direction <= YawRight
+}
+
+func (fpsController) Move(c *Camera, direction Direction, amount float32) {
 	toTarget := c.TargetVector()
 	forward := c.ForwardsVector()
 	right := c.RightWardsVector()
 	up := c.UpwardsVector()
 
 	switch direction {
-	case 0: // Move forward
+	case Forward: // Move forward
 		c.Position = c.Position.Add(forward.Mul(amount))
 		c.Target = c.Position.Add(toTarget)
-	case 1: // Move backward
+	case Backward: // Move backward
 		c.Position = c.Position.Sub(forward.Mul(amount))
 		c.Target = c.Position.Add(toTarget)
-	case 2: // Strafe left
+	case Left: // Strafe left
 		c.Position = c.Position.Sub(right.Mul(amount))
 		c.Target = c.Position.Add(toTarget)
-	case 3: // Strafe right
+	case Right: // Strafe right
 		c.Position = c.Position.Add(right.Mul(amount))
 		c.Target = c.Position.Add(toTarget)
-	case 4: // Move up
+	case Up: // Move up
 		c.Position = c.Position.Add(up.Mul(amount))
 		c.Target = c.Position.Add(toTarget)
-	case 5: // Move down
+	case Down: // Move down
 		c.Position = c.Position.Sub(up.Mul(amount))
 		c.Target = c.Position.Add(toTarget)
-	case 6: // Pitch up
-		//Rotate target around the camera's right vector by the specified amount
+	case PitchUp: // Pitch up
+		//Rotate target around the camera's right vector by the specified amount, stopping short of straight up
+		amount = clampPitch(elevation(toTarget, c.Up), amount)
 		newTarget := mgl32.HomogRotate3D(amount, right).Mul4x1(toTarget.Vec4(0))
 		c.Target = c.Position.Add(newTarget.Vec3())
-	case 7: // Pitch down
-		//Rotate target around the camera's right vector by the specified amount
+	case PitchDown: // Pitch down
+		//Rotate target around the camera's right vector by the specified amount, stopping short of straight down
+		amount = -clampPitch(elevation(toTarget, c.Up), -amount)
 		newTarget := mgl32.HomogRotate3D(-amount, right).Mul4x1(toTarget.Vec4(0))
 		c.Target = c.Position.Add(newTarget.Vec3())
-	case 8: // Yaw left
+	case YawLeft: // Yaw left
 		//Rotate target around the camera's up vector by the specified amount
 		newTarget := mgl32.HomogRotate3D(amount, up).Mul4x1(toTarget.Vec4(0))
 		c.Target = c.Position.Add(newTarget.Vec3())
-	case 9: // Yaw right
+	case YawRight: // Yaw right
 		//Rotate target around the camera's up vector by the specified amount
 		newTarget := mgl32.HomogRotate3D(-amount, up).Mul4x1(toTarget.Vec4(0))
 		c.Target = c.Position.Add(newTarget.Vec3())
-	case 10: // Roll left (Not applicable in FPS mode)
-	case 11: // Roll right (Not applicable in FPS mode)
+	case RollLeft: // Roll left (Not applicable in FPS mode)
+	case RollRight: // Roll right (Not applicable in FPS mode)
 	}
-	c.Orientation = mgl32.Mat4ToQuat(mgl32.LookAtV(c.Position, c.Target, c.Up))
+	//Renormalise the target vector, so that rounding in the rotations does not make it drift in length over a long session
+	distance := max(toTarget.Len(), MinTargetDistance)
+	if newToTarget := c.Target.Sub(c.Position); newToTarget.Len() > 0 {
+		c.Target = c.Position.Add(newToTarget.Normalize().Mul(distance))
+	}
+	c.LookAt(c.Target.Elem())
 }
 
 // Project a vector onto a plane, given the normal of the plane, where v1 is the normal of the plane and v2 is the vector to be projected
@@ -418,7 +514,7 @@ func ProjectPlane(v1, v2 mgl32.Vec3) mgl32.Vec3 {
 // rayDirection is the direction of the ray
 // Returns the point on the plane that the ray intercepts
 //
-// The plane is assumed to pass through the origin
+// The plane is assumed to pass through the origin.  A ray parallel to the plane returns the origin, and a plane behind the ray is still hit; use PlaneInterceptChecked to detect both.
 func PlaneIntercept(groundNormal, rayOrigin, rayDirection mgl32.Vec3) mgl32.Vec3 {
 
 	groundNormal = groundNormal.Normalize()
@@ -432,8 +528,15 @@ func PlaneIntercept(groundNormal, rayOrigin, rayDirection mgl32.Vec3) mgl32.Vec3
 	return rayOrigin.Add(rayDirection.Mul(t))
 }
 
+// PlaneInterceptChecked is PlaneIntercept, but ok is false if the ray is parallel to the plane, or the plane is behind the ray.
+// A zero normal or direction also gives false.
+func PlaneInterceptChecked(groundNormal, rayOrigin, rayDirection mgl32.Vec3) (point mgl32.Vec3, ok bool) {
+	return PlaneIntercept2Checked(mgl32.Vec3{}, groundNormal, rayOrigin, rayDirection)
+}
+
 // Find the point on the plane that the ray intercepts
 // as for PlaneIntercept, but the plane is not assumed to pass through the origin
+// Use PlaneIntercept2Checked to detect parallel rays, and planes behind the ray.
 func PlaneIntercept2(groundOrigin, groundNormal, rayOrigin, rayDirection mgl32.Vec3) mgl32.Vec3 {
 	//Find the point on the plane that the ray intercepts
 	//groundOrigin is a point on the plane
@@ -452,67 +555,102 @@ func PlaneIntercept2(groundOrigin, groundNormal, rayOrigin, rayDirection mgl32.V
 	return rayOrigin.Add(rayDirection.Mul(t))
 }
 
-func (c *Camera) moveRTSMode(direction int, amount float32) {
+// PlaneIntercept2Checked is PlaneIntercept2, but ok is false if the ray is parallel to the plane, or the plane is behind the ray.
+// A zero normal or direction also gives false.
+func PlaneIntercept2Checked(groundOrigin, groundNormal, rayOrigin, rayDirection mgl32.Vec3) (point mgl32.Vec3, ok bool) {
+	if groundNormal.Len() == 0 || rayDirection.Len() == 0 {
+		return mgl32.Vec3{}, false
+	}
+	groundNormal = groundNormal.Normalize()
+	rayDirection = rayDirection.Normalize()
+	d := groundNormal.Dot(rayDirection)
+	if abs(d) < parallelEpsilon {
+		//Ray is parallel to the plane, or so close to it that the intercept is meaningless
+		return mgl32.Vec3{}, false
+	}
+	t := groundNormal.Dot(groundOrigin.Sub(rayOrigin)) / d
+	if t < 0 || math.IsInf(float64(t), 0) || math.IsNaN(float64(t)) {
+		//The plane is behind the ray
+		return mgl32.Vec3{}, false
+	}
+	return rayOrigin.Add(rayDirection.Mul(t)), true
+}
+
+// rtsController pans the camera over the ground plane, and orbits a point on it.
+type rtsController struct{}
+
+func (rtsController) Update(c *Camera, dt float32) {}
+
+func (rtsController) Supports(direction Direction) bool {
+	return direction >= Forward && direction <= YawRight
+}
+
+func (rtsController) Move(c *Camera, direction Direction, amount float32) {
 	forward := c.ForwardsVector()
 	up := c.UpwardsVector()
 
 	//Project the camera's forward vector onto the ground plane, held in c.groundPlaneNormal
-	groundForwardVec := ProjectPlane(c.GroundPlaneNormal, forward).Normalize()
+	groundForwardVec := ProjectPlane(c.GroundPlaneNormal, forward)
+	if groundForwardVec.Len() < parallelEpsilon {
+		//Looking straight down, so the top of the screen is the way ahead
+		groundForwardVec = ProjectPlane(c.GroundPlaneNormal, up)
+	}
+	groundForwardVec = groundForwardVec.Normalize()
 	groundRightVec := up.Cross(groundForwardVec).Normalize()
-	target := PlaneIntercept(c.GroundPlaneNormal, c.Position, forward)
+	target := c.groundTarget(forward, up)
 	c.Target = target
 	//Camera position relative to the target, in this case the ground intercept point
 	relativePosition := c.Position.Sub(c.Target)
 
 	switch direction {
-	case 0: // Pan forward
+	case Forward: // Pan forward
 		c.Position = c.Position.Add(groundForwardVec.Mul(amount))
 
-	case 1: // Pan backward
+	case Backward: // Pan backward
 		c.Position = c.Position.Sub(groundForwardVec.Mul(amount))
 		c.Target = c.Position.Add(forward)
 
-	case 2: // Pan left
+	case Left: // Pan left
 		c.Position = c.Position.Add(groundRightVec.Mul(amount))
 		c.Target = c.Position.Add(forward)
 
-	case 3: // Pan right
+	case Right: // Pan right
 		c.Position = c.Position.Sub(groundRightVec.Mul(amount))
 		c.Target = c.Position.Add(forward)
 
-	case 10: // Roll left (Not applicable in RTS mode)
-	case 11: // Roll right (Not applicable in RTS mode)
+	case RollLeft: // Roll left (Not applicable in RTS mode)
+	case RollRight: // Roll right (Not applicable in RTS mode)
 
-	case 4: // Zoom in
+	case Up: // Zoom in
+		//Stop at MinZoomDistance, rather than passing through the ground
+		amount = min(amount, max(relativePosition.Len()-max(c.MinZoomDistance, MinTargetDistance), 0))
 		c.Position = c.Position.Add(forward.Mul(amount))
 		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
 		c.Target = c.Position.Add(forward)
-	case 5: // Zoom out
+		c.scaleOrthoHeight(relativePosition.Len(), c.Position.Sub(target).Len())
+	case Down: // Zoom out
 		c.Position = c.Position.Sub(forward.Mul(amount))
 		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
 		c.Target = c.Position.Add(forward)
-	case 8: // Orbit left
+		c.scaleOrthoHeight(relativePosition.Len(), c.Position.Sub(target).Len())
+	case YawLeft: // Orbit left
 		//Rotate the camera around the target by the specified amount
 		new_relative_position := mgl32.HomogRotate3D(amount, c.GroundPlaneNormal).Mul4x1(relativePosition.Vec4(0))
 		c.Position = c.Target.Add(new_relative_position.Vec3())
 		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 9: // Orbit right
+	case YawRight: // Orbit right
 		//Rotate the camera around the target by the specified amount
 		//FIXME rotate around the ground plane normal, not the axis
 		new_relative_position := mgl32.HomogRotate3D(-amount, c.GroundPlaneNormal).Mul4x1(relativePosition.Vec4(0))
 		c.Position = c.Target.Add(new_relative_position.Vec3())
 		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
 
-	case 6: //Orbit up
+	case PitchUp: //Orbit up
 		//FIXME rotate around the camera's right vector, not the axis
-		new_relative_position := mgl32.HomogRotate3D(-amount, groundRightVec).Mul4x1(relativePosition.Vec4(0))
-		c.Position = c.Target.Add(new_relative_position.Vec3())
-		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
-	case 7: // Orbit down
+		c.orbitRTSPitch(-amount, groundRightVec, relativePosition)
+	case PitchDown: // Orbit down
 		//FIXME rotate around the camera's right vector, not the axis
-		new_relative_position := mgl32.HomogRotate3D(amount, groundRightVec).Mul4x1(relativePosition.Vec4(0))
-		c.Position = c.Target.Add(new_relative_position.Vec3())
-		c.LookAt(c.Target.X(), c.Target.Y(), c.Target.Z())
+		c.orbitRTSPitch(amount, groundRightVec, relativePosition)
 	}
 
 }
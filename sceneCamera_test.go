@@ -43,9 +43,65 @@ func assertPanics(t *testing.T, fn func()) {
 	fn()
 }
 
+// assertVec3Near compares vectors with an absolute tolerance, for components that should be zero, which assertVec3 compares far more strictly.
+func assertVec3Near(t *testing.T, actual, expected mgl32.Vec3, tolerance float32) {
+	t.Helper()
+	if actual.Sub(expected).Len() > tolerance {
+		t.Errorf("expected vector %v, got %v", expected, actual)
+	}
+}
+
+func assertVec2(t *testing.T, actual, expected mgl32.Vec2) {
+	t.Helper()
+	if !actual.ApproxEqualThreshold(expected, 1e-2) {
+		t.Errorf("expected screen position %v, got %v", expected, actual)
+	}
+}
+
+func assertFloat(t *testing.T, actual, expected float32) {
+	t.Helper()
+	if math.Abs(float64(actual-expected)) > 1e-4 {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func assertFiniteQuat(t *testing.T, q mgl32.Quat) {
+	t.Helper()
+	for _, value := range []float32{q.W, q.V.X(), q.V.Y(), q.V.Z()} {
+		if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+			t.Fatalf("expected a finite orientation, got %v", q)
+		}
+	}
+	if math.Abs(float64(q.Len()-1)) > 1e-4 {
+		t.Errorf("expected a unit orientation, got length %v", q.Len())
+	}
+}
+
+// testPose is a starting view for the tests: a camera from New, moved to position and pointed at target.
+type testPose struct {
+	mode             Mode
+	position, target mgl32.Vec3
+	up               mgl32.Vec3
+}
+
+// The starting views shared by the tests.
+var (
+	flyingPose    = testPose{Flight, mgl32.Vec3{0, 0, 10}, mgl32.Vec3{5, 0, 10}, mgl32.Vec3{0, 0, 1}} //Flying level along +X, with +Z up
+	walkingPose   = testPose{FPS, mgl32.Vec3{0, 0, 2}, mgl32.Vec3{10, 0, 0}, mgl32.Vec3{0, 0, 1}}     //Standing 2 above the ground, with +Z up, looking along +X and down at the ground 10 ahead
+	zUpMuseumPose = testPose{Museum, mgl32.Vec3{0, -5, 0}, mgl32.Vec3{}, mgl32.Vec3{0, 0, 1}}         //Orbiting the origin, with +Z up
+)
+
+func (p testPose) camera() *Camera {
+	camera := New(p.mode)
+	camera.SetUp(p.up.Elem())
+	camera.SetPosition(p.position.Elem())
+	camera.LookAt(p.target.Elem())
+	return camera
+}
+
 func TestNew(t *testing.T) {
 	testCases := []struct {
-		mode     int
+		mode     Mode
 		position mgl32.Vec3
 		up       mgl32.Vec3
 	}{
@@ -55,7 +111,7 @@ func TestNew(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		t.Run(fmt.Sprintf("mode-%d", testCase.mode), func(t *testing.T) {
+		t.Run(fmt.Sprintf("mode-%d", int(testCase.mode)), func(t *testing.T) {
 			camera := New(testCase.mode)
 			if camera.Mode != testCase.mode {
 				t.Errorf("expected mode %d, got %d", testCase.mode, camera.Mode)
@@ -159,12 +215,16 @@ func TestFrustumValidation(t *testing.T) {
 		{name: "right-near", clear: func(camera *Camera) { camera.Near = 0 }, method: (*Camera).RightEyeFrustum},
 		{name: "right-far", clear: func(camera *Camera) { camera.Far = 0 }, method: (*Camera).RightEyeFrustum},
 		{name: "right-fov", clear: func(camera *Camera) { camera.FOV = 0 }, method: (*Camera).RightEyeFrustum},
+		{name: "right-focal-length", clear: func(camera *Camera) { camera.FocalLength = 0 }, method: (*Camera).RightEyeFrustum},
+		{name: "right-toe-in-focal-length", clear: func(camera *Camera) { camera.Stereo, camera.FocalLength = ToeIn, 0 }, method: (*Camera).RightEyeFrustum},
 		{name: "left-height", clear: func(camera *Camera) { camera.Screenheight = 0 }, method: (*Camera).LeftEyeFrustum},
 		{name: "left-width", clear: func(camera *Camera) { camera.Screenwidth = 0 }, method: (*Camera).LeftEyeFrustum},
 		{name: "left-ipd", clear: func(camera *Camera) { camera.IPD = 0 }, method: (*Camera).LeftEyeFrustum},
 		{name: "left-near", clear: func(camera *Camera) { camera.Near = 0 }, method: (*Camera).LeftEyeFrustum},
 		{name: "left-far", clear: func(camera *Camera) { camera.Far = 0 }, method: (*Camera).LeftEyeFrustum},
 		{name: "left-fov", clear: func(camera *Camera) { camera.FOV = 0 }, method: (*Camera).LeftEyeFrustum},
+		{name: "left-focal-length", clear: func(camera *Camera) { camera.FocalLength = 0 }, method: (*Camera).LeftEyeFrustum},
+		{name: "left-toe-in-focal-length", clear: func(camera *Camera) { camera.Stereo, camera.FocalLength = ToeIn, 0 }, method: (*Camera).LeftEyeFrustum},
 	}
 
 	for _, testCase := range testCases {
@@ -186,8 +246,8 @@ func TestDirectionVectors(t *testing.T) {
 }
 
 func TestMuseumMovement(t *testing.T) {
-	for direction := 0; direction <= 11; direction++ {
-		t.Run(fmt.Sprintf("direction-%d", direction), func(t *testing.T) {
+	for direction := Forward; direction <= RollRight; direction++ {
+		t.Run(fmt.Sprintf("direction-%d", int(direction)), func(t *testing.T) {
 			camera := New(1)
 			original := camera.Position
 			originalDistance := camera.Position.Sub(camera.Target).Len()
@@ -213,7 +273,7 @@ func TestMuseumMovement(t *testing.T) {
 }
 
 func TestFPSMovement(t *testing.T) {
-	positions := map[int]mgl32.Vec3{
+	positions := map[Direction]mgl32.Vec3{
 		0: {0, 0, 4},
 		1: {0, 0, 6},
 		2: {-1, 0, 5},
@@ -221,8 +281,8 @@ func TestFPSMovement(t *testing.T) {
 		4: {0, 1, 5},
 		5: {0, -1, 5},
 	}
-	for direction := 0; direction <= 11; direction++ {
-		t.Run(fmt.Sprintf("direction-%d", direction), func(t *testing.T) {
+	for direction := Forward; direction <= RollRight; direction++ {
+		t.Run(fmt.Sprintf("direction-%d", int(direction)), func(t *testing.T) {
 			camera := New(2)
 			originalTarget := camera.Target
 			originalUp := camera.Up
@@ -246,8 +306,8 @@ func TestFPSMovement(t *testing.T) {
 }
 
 func TestRTSMovement(t *testing.T) {
-	for direction := 0; direction <= 11; direction++ {
-		t.Run(fmt.Sprintf("direction-%d", direction), func(t *testing.T) {
+	for direction := Forward; direction <= RollRight; direction++ {
+		t.Run(fmt.Sprintf("direction-%d", int(direction)), func(t *testing.T) {
 			camera := New(3)
 			original := camera.Position
 			camera.Move(direction, 0.25)
@@ -0,0 +1,104 @@
+package sceneCamera
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Screen is a physical display rectangle in world space, for head-tracked displays such as fish-tank VR and CAVE walls.
+// The corners are given as seen from in front of the screen, where the viewer is.
+type Screen struct {
+	LowerLeft  mgl32.Vec3
+	LowerRight mgl32.Vec3
+	UpperLeft  mgl32.Vec3
+}
+
+// UpperRight returns the fourth corner of the screen.
+func (s Screen) UpperRight() mgl32.Vec3 {
+	return s.LowerRight.Add(s.UpperLeft.Sub(s.LowerLeft))
+}
+
+// Center returns the middle of the screen.
+func (s Screen) Center() mgl32.Vec3 {
+	return s.LowerRight.Add(s.UpperLeft).Mul(0.5)
+}
+
+// axes returns the screen's unit right and up vectors, and its normal, which points out of the front of the screen.
+func (s Screen) axes() (right, up, normal mgl32.Vec3) {
+	right = s.LowerRight.Sub(s.LowerLeft).Normalize()
+	up = s.UpperLeft.Sub(s.LowerLeft).Normalize()
+	return right, up, right.Cross(up).Normalize()
+}
+
+// validate checks that the corners make a rectangle.
+func (s Screen) validate(v *validation) {
+	width, height := s.LowerRight.Sub(s.LowerLeft), s.UpperLeft.Sub(s.LowerLeft)
+	if !isFiniteVec3(s.LowerLeft) || !isFiniteVec3(s.LowerRight) || !isFiniteVec3(s.UpperLeft) {
+		v.add("Screen", "is not finite")
+	} else if width.Len() < parallelEpsilon || height.Len() < parallelEpsilon {
+		v.add("Screen", "has no area")
+	} else if math.Abs(float64(width.Normalize().Dot(height.Normalize()))) > 1e-3 {
+		v.add("Screen", "is not a rectangle")
+	}
+}
+
+// ScreenEyePosition returns where the eye is, for ScreenProjection: the camera's position, moved half the IPD along its right vector for LeftEye or RightEye.
+// Set the camera's position and orientation from the head tracker.
+func (c *Camera) ScreenEyePosition(eye Eye) mgl32.Vec3 {
+	var side float32
+	switch eye {
+	case LeftEye:
+		side = -1
+	case RightEye:
+		side = 1
+	}
+	return c.Position.Add(c.RightWardsVector().Mul(side * c.IPD / 2))
+}
+
+// ScreenProjection returns the view and projection matrices for looking at the screen from the eye, using Kooima's generalised perspective projection.
+// The frustum runs from the eye through the edges of the screen, so the image lines up with the physical screen wherever the viewer's head is.
+// The view matrix faces the screen square on, whichever way the camera is facing, and the eyes are placed by ScreenEyePosition, so both eyes of a stereo pair share the same screen.
+// Stereo is not used: points on the screen always have no disparity.
+//
+// It returns a *ValidationError if the corners do not make a rectangle, the eye is not in front of the screen, or Near and Far are invalid.
+func (c *Camera) ScreenProjection(screen Screen, eye Eye) (view, projection mgl32.Mat4, err error) {
+	var v validation
+	screen.validate(&v)
+	v.positive("Near", c.Near)
+	if !isFinite(c.Far) {
+		v.add("Far", "is not finite")
+	} else if c.Far <= c.Near {
+		v.add("Far", "is not greater than Near")
+	}
+	if err := v.err(); err != nil {
+		return view, projection, err
+	}
+
+	right, up, normal := screen.axes()
+	position := c.ScreenEyePosition(eye)
+	//Vectors from the eye to the corners
+	toLowerLeft := screen.LowerLeft.Sub(position)
+	toLowerRight := screen.LowerRight.Sub(position)
+	toUpperLeft := screen.UpperLeft.Sub(position)
+
+	//The distance from the eye to the plane of the screen
+	distance := -toLowerLeft.Dot(normal)
+	if !(distance > parallelEpsilon) {
+		v.add("Position", "is not in front of the screen")
+		return view, projection, v.err()
+	}
+
+	//The screen's extent, scaled back to the near plane
+	scale := c.Near / distance
+	left := right.Dot(toLowerLeft) * scale
+	rightEdge := right.Dot(toLowerRight) * scale
+	bottom := up.Dot(toLowerLeft) * scale
+	top := up.Dot(toUpperLeft) * scale
+	projection = mgl32.Frustum(left, rightEdge, bottom, top, c.Near, c.Far)
+
+	//Rotate the screen's axes onto the view axes, with the eye at the origin
+	rotation := mgl32.Mat4FromRows(right.Vec4(0), up.Vec4(0), normal.Vec4(0), mgl32.Vec4{0, 0, 0, 1})
+	view = rotation.Mul4(mgl32.Translate3D(-position.X(), -position.Y(), -position.Z()))
+	return view, projection, nil
+}
@@ -0,0 +1,137 @@
+package sceneCamera
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// testWallScreen is a 4 by 2 screen on the z=0 plane, facing +z, with its lower left corner at (-2, 0, 0).
+var testWallScreen = Screen{
+	LowerLeft:  mgl32.Vec3{-2, 0, 0},
+	LowerRight: mgl32.Vec3{2, 0, 0},
+	UpperLeft:  mgl32.Vec3{-2, 2, 0},
+}
+
+// screenNDC projects a world-space point through a view and projection, to normalised device coordinates.
+func screenNDC(view, projection mgl32.Mat4, p mgl32.Vec3) mgl32.Vec3 {
+	return mgl32.TransformCoordinate(p, projection.Mul4(view))
+}
+
+func TestScreenCorners(t *testing.T) {
+	screen := Screen{LowerLeft: mgl32.Vec3{1, 2, 3}, LowerRight: mgl32.Vec3{5, 2, 3}, UpperLeft: mgl32.Vec3{1, 4, 3}}
+	assertVec3(t, screen.UpperRight(), mgl32.Vec3{5, 4, 3})
+	assertVec3(t, screen.Center(), mgl32.Vec3{3, 3, 3})
+}
+
+func TestScreenProjectionCentred(t *testing.T) {
+	camera := New(FPS)
+	camera.Position = mgl32.Vec3{0, 1, 4}
+	camera.LookAt(0, 1, 0)
+	view, projection, err := camera.ScreenProjection(testWallScreen, MonoEye)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//Square on to the middle of the screen, this is an ordinary perspective projection
+	fov := float32(2 * math.Atan(1.0/4))
+	assertMat4(t, projection, mgl32.Perspective(fov, 2, camera.Near, camera.Far))
+	assertMat4(t, view, camera.ViewMatrix())
+}
+
+func TestScreenProjectionCornersLineUp(t *testing.T) {
+	//A wall turned to face a viewer who is off to one side, and looking elsewhere
+	turn := mgl32.QuatRotate(0.6, mgl32.Vec3{0, 1, 0}).Mat4()
+	screen := Screen{
+		LowerLeft:  mgl32.TransformCoordinate(testWallScreen.LowerLeft, turn),
+		LowerRight: mgl32.TransformCoordinate(testWallScreen.LowerRight, turn),
+		UpperLeft:  mgl32.TransformCoordinate(testWallScreen.UpperLeft, turn),
+	}
+	camera := New(FPS)
+	camera.Position = mgl32.Vec3{3, 1.5, 3}
+	camera.LookAt(-1, 0.5, 0)
+	camera.SetIPD(0.065)
+
+	corners := []struct {
+		world mgl32.Vec3
+		x, y  float32
+	}{
+		{screen.LowerLeft, -1, -1},
+		{screen.LowerRight, 1, -1},
+		{screen.UpperLeft, -1, 1},
+		{screen.UpperRight(), 1, 1},
+		{screen.Center(), 0, 0},
+	}
+	for _, eye := range []Eye{MonoEye, LeftEye, RightEye} {
+		t.Run(eye.String(), func(t *testing.T) {
+			view, projection, err := camera.ScreenProjection(screen, eye)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, corner := range corners {
+				ndc := screenNDC(view, projection, corner.world)
+				if math.Abs(float64(ndc.X()-corner.x)) > 1e-4 || math.Abs(float64(ndc.Y()-corner.y)) > 1e-4 {
+					t.Errorf("expected %v at (%v, %v), got %v", corner.world, corner.x, corner.y, ndc)
+				}
+			}
+		})
+	}
+}
+
+func TestScreenProjectionStereo(t *testing.T) {
+	camera := New(FPS)
+	camera.Position = mgl32.Vec3{0.5, 1, 3}
+	camera.LookAt(0.5, 1, 0)
+	camera.SetIPD(0.065)
+	assertVec3(t, camera.ScreenEyePosition(LeftEye), mgl32.Vec3{0.5 - 0.0325, 1, 3})
+	assertVec3(t, camera.ScreenEyePosition(RightEye), mgl32.Vec3{0.5 + 0.0325, 1, 3})
+	assertVec3(t, camera.ScreenEyePosition(MonoEye), camera.Position)
+
+	leftView, leftProjection, err := camera.ScreenProjection(testWallScreen, LeftEye)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rightView, rightProjection, err := camera.ScreenProjection(testWallScreen, RightEye)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//Points on the screen are in the same place for both eyes, points behind it are not
+	onScreen := mgl32.Vec3{0.7, 1.3, 0}
+	behind := mgl32.Vec3{0.7, 1.3, -2}
+	if d := screenNDC(rightView, rightProjection, onScreen).Sub(screenNDC(leftView, leftProjection, onScreen)); d.Vec2().Len() > 1e-5 {
+		t.Errorf("expected no disparity on the screen, got %v", d)
+	}
+	if d := screenNDC(rightView, rightProjection, behind).Sub(screenNDC(leftView, leftProjection, behind)); d.X() <= 0 {
+		t.Errorf("expected positive disparity behind the screen, got %v", d)
+	}
+}
+
+func TestScreenProjectionErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		screen   Screen
+		change   func(c *Camera)
+		expected []string
+	}{
+		{"no area", Screen{LowerRight: mgl32.Vec3{1, 0, 0}}, func(c *Camera) {}, []string{"Screen"}},
+		{"not a rectangle", Screen{LowerRight: mgl32.Vec3{1, 0, 0}, UpperLeft: mgl32.Vec3{1, 1, 0}}, func(c *Camera) {}, []string{"Screen"}},
+		{"behind the screen", testWallScreen, func(c *Camera) { c.Position = mgl32.Vec3{0, 1, -3} }, []string{"Position"}},
+		{"on the screen", testWallScreen, func(c *Camera) { c.Position = mgl32.Vec3{0, 1, 0} }, []string{"Position"}},
+		{"clipping planes", testWallScreen, func(c *Camera) { c.Near, c.Far = 0, 0 }, []string{"Near", "Far"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			camera := New(FPS)
+			testCase.change(camera)
+			_, _, err := camera.ScreenProjection(testCase.screen, MonoEye)
+			if !errors.Is(err, ErrInvalidCamera) {
+				t.Fatalf("expected ErrInvalidCamera, got %v", err)
+			}
+			if fields := invalidFields(t, err); !reflect.DeepEqual(fields, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package sceneCamera
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Frame is a consistent snapshot of a camera's matrices and state, published by SharedCamera.
+// Every field comes from the same moment, so the view and projection never tear.  A Frame is shared between readers, so do not modify it.
+type Frame struct {
+	State          CameraState //Everything Snapshot saves, including Mode and the screen size
+	Forward        mgl32.Vec3  //The camera's forward unit vector
+	Right          mgl32.Vec3  //The camera's right unit vector
+	Up             mgl32.Vec3  //The camera's up unit vector
+	View           mgl32.Mat4  //From ViewMatrix
+	Projection     mgl32.Mat4  //From ProjectionMatrix.  Zero if ProjectionErr is set
+	ViewProjection mgl32.Mat4  //Projection times View
+	ProjectionErr  error       //The error from ProjectionMatrixChecked, for example while the window has no size
+
+	LeftEyeView        mgl32.Mat4 //From LeftEyeViewMatrix
+	RightEyeView       mgl32.Mat4 //From RightEyeViewMatrix
+	LeftEyeProjection  mgl32.Mat4 //From LeftEyeFrustum.  Zero if StereoErr is set
+	RightEyeProjection mgl32.Mat4 //From RightEyeFrustum.  Zero if StereoErr is set
+	StereoErr          error      //The error from the checked eye frustums, for example when IPD is zero
+}
+
+// SharedCamera lets several goroutines use one Camera.
+//
+// Any goroutine can queue commands, such as Move or SetDesiredVelocity, which are applied in order by the next Update.  Do runs a function on the camera straight away, for anything that needs an answer.
+// After each Update or Do, the camera's matrices are published as a Frame, which the render thread reads with Frame without waiting for the camera.
+//
+// Once a camera is shared, only use it through the SharedCamera.
+type SharedCamera struct {
+	lock   sync.Mutex //Held while the camera is in use
+	camera *Camera
+
+	queueLock sync.Mutex
+	queue     []func(camera *Camera) //Commands waiting for the next Update
+
+	frame atomic.Pointer[Frame] //The latest published frame
+}
+
+// NewSharedCamera shares a camera, and publishes its first Frame.
+func NewSharedCamera(camera *Camera) *SharedCamera {
+	s := &SharedCamera{camera: camera}
+	s.publish()
+	return s
+}
+
+// Queue adds a command to be run on the camera by the next Update, after the commands queued before it.  It can be called from any goroutine, and does not wait for the camera.
+func (s *SharedCamera) Queue(command func(camera *Camera)) {
+	s.queueLock.Lock()
+	s.queue = append(s.queue, command)
+	s.queueLock.Unlock()
+}
+
+// Move queues a Camera.Move.
+func (s *SharedCamera) Move(direction Direction, amount float32) {
+	s.Queue(func(c *Camera) { c.Move(direction, amount) })
+}
+
+// SetDesiredVelocity queues a Camera.SetDesiredVelocity.
+func (s *SharedCamera) SetDesiredVelocity(direction Direction, velocity float32) {
+	s.Queue(func(c *Camera) { c.SetDesiredVelocity(direction, velocity) })
+}
+
+// Update runs the queued commands, moves the camera on by dt seconds with Camera.Update, and publishes a new Frame.
+func (s *SharedCamera) Update(dt float32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runQueue()
+	s.camera.Update(dt)
+	s.publish()
+}
+
+// Do runs fn on the camera straight away, after any queued commands, and then publishes a new Frame.
+// The camera is locked while fn runs, so fn must not keep the camera, or call the SharedCamera's Update or Do.
+func (s *SharedCamera) Do(fn func(camera *Camera)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runQueue()
+	fn(s.camera)
+	s.publish()
+}
+
+// Frame returns the latest published Frame.  It never waits for the camera.
+func (s *SharedCamera) Frame() *Frame {
+	return s.frame.Load()
+}
+
+// runQueue takes the queued commands and runs them.  The camera lock must be held.
+func (s *SharedCamera) runQueue() {
+	s.queueLock.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.queueLock.Unlock()
+	for _, command := range queue {
+		command(s.camera)
+	}
+}
+
+// publish builds a Frame from the camera, and makes it the latest.  The camera lock must be held.
+func (s *SharedCamera) publish() {
+	c := s.camera
+	frame := &Frame{
+		State:        c.Snapshot(),
+		Forward:      c.ForwardsVector(),
+		Right:        c.RightWardsVector(),
+		Up:           c.UpwardsVector(),
+		View:         c.ViewMatrix(),
+		LeftEyeView:  c.LeftEyeViewMatrix(),
+		RightEyeView: c.RightEyeViewMatrix(),
+	}
+	frame.Projection, frame.ProjectionErr = c.ProjectionMatrixChecked()
+	if frame.ProjectionErr == nil {
+		frame.ViewProjection = frame.Projection.Mul4(frame.View)
+	}
+	frame.LeftEyeProjection, frame.StereoErr = c.LeftEyeFrustumChecked()
+	if frame.StereoErr == nil {
+		frame.RightEyeProjection, frame.StereoErr = c.RightEyeFrustumChecked()
+	}
+	s.frame.Store(frame)
+}
@@ -0,0 +1,121 @@
+package sceneCamera
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestSharedCameraQueue(t *testing.T) {
+	shared := NewSharedCamera(New(FPS))
+	shared.Move(Forward, 1)
+	shared.Move(Right, 2)
+	//Nothing happens until Update
+	assertVec3(t, shared.Frame().State.Position, mgl32.Vec3{0, 0, 5})
+
+	shared.Update(0.01)
+	assertVec3(t, shared.Frame().State.Position, mgl32.Vec3{2, 0, 4})
+
+	//Do runs any queued commands first, in order
+	shared.Move(Backward, 1)
+	var position mgl32.Vec3
+	shared.Do(func(c *Camera) { position = c.Position })
+	assertVec3(t, position, mgl32.Vec3{2, 0, 5})
+	assertVec3(t, shared.Frame().State.Position, position)
+}
+
+func TestSharedCameraDesiredVelocity(t *testing.T) {
+	camera := New(FPS)
+	camera.Acceleration, camera.Damping = 0, 0
+	shared := NewSharedCamera(camera)
+	shared.SetDesiredVelocity(Forward, 2)
+	shared.Update(0.5)
+	assertVec3(t, shared.Frame().State.Position, mgl32.Vec3{0, 0, 4})
+}
+
+func TestSharedCameraFrameErrors(t *testing.T) {
+	shared := NewSharedCamera(New(Museum))
+	frame := shared.Frame()
+	if frame.ProjectionErr != nil || frame.StereoErr != nil {
+		t.Fatalf("expected no errors, got %v and %v", frame.ProjectionErr, frame.StereoErr)
+	}
+	assertMat4(t, frame.ViewProjection, frame.Projection.Mul4(frame.View))
+
+	//A minimised window
+	shared.Do(func(c *Camera) { c.Screenwidth, c.Screenheight = 0, 0 })
+	frame = shared.Frame()
+	if frame.ProjectionErr == nil || frame.StereoErr == nil {
+		t.Errorf("expected errors for a zero screen size")
+	}
+	if frame.Projection != (mgl32.Mat4{}) {
+		t.Errorf("expected a zero projection, got %v", frame.Projection)
+	}
+	assertFiniteMat4(t, frame.View)
+}
+
+// TestSharedCameraConcurrency is most useful with go test -race.
+func TestSharedCameraConcurrency(t *testing.T) {
+	shared := NewSharedCamera(New(FPS))
+	var writers, readers sync.WaitGroup
+	done := make(chan struct{})
+
+	for i := 0; i < 4; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for j := 0; j < 200; j++ {
+				shared.Move(YawLeft, 0.01)
+				shared.Move(Forward, 0.01)
+			}
+		}()
+	}
+	writers.Add(1)
+	go func() {
+		defer writers.Done()
+		for j := 0; j < 200; j++ {
+			shared.Update(0.001)
+		}
+	}()
+
+	for i := 0; i < 2; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				//Each frame's matrices agree with its own state
+				frame := shared.Frame()
+				state := frame.State
+				rotation := state.Orientation.Mat4()
+				expected := rotation.Mul4(mgl32.Translate3D(-state.Position.X(), -state.Position.Y(), -state.Position.Z()))
+				if frame.View != expected {
+					t.Errorf("expected the view to match the frame's state, got %v and %v", frame.View, expected)
+					return
+				}
+				if frame.ViewProjection != frame.Projection.Mul4(frame.View) {
+					t.Errorf("expected the view projection to match the frame's view and projection")
+					return
+				}
+			}
+		}()
+	}
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	//Every queued command has been applied
+	shared.Update(0.001)
+	expected := New(FPS)
+	for j := 0; j < 800; j++ {
+		expected.Move(YawLeft, 0.01)
+	}
+	forward := shared.Frame().Forward
+	if forward.Dot(expected.ForwardsVector()) < 1-1e-3 {
+		t.Errorf("expected all the turns to be applied, got forward %v, expected %v", forward, expected.ForwardsVector())
+	}
+}
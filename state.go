@@ -0,0 +1,309 @@
+package sceneCamera
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// StateVersion is the version written by MarshalJSON and MarshalBinary.  State from a newer version is rejected with ErrStateVersion.
+const StateVersion = 1
+
+// ErrStateVersion is returned when decoding camera state written by a newer version of this package, or with no version at all.
+var ErrStateVersion = errors.New("unsupported camera state version")
+
+// ErrStateFormat is returned when decoding binary camera state that is truncated or is not camera state.
+var ErrStateFormat = errors.New("invalid camera state")
+
+// stateMagic starts every binary camera state.
+var stateMagic = [4]byte{'S', 'C', 'A', 'M'}
+
+// CameraState is a copy of everything needed to put a camera back where it was: its pose, mode, projection and stereo settings, zoom limits and movement tuning.
+// It is a plain value, so it can be stored, compared and sent without sharing anything with the camera it came from.
+//
+// Movement in progress, such as velocities from Update or a ground drag, is not part of the state.
+type CameraState struct {
+	Version           int            `json:"version"`
+	Position          mgl32.Vec3     `json:"position"`
+	Target            mgl32.Vec3     `json:"target"`
+	Up                mgl32.Vec3     `json:"up"`
+	Orientation       mgl32.Quat     `json:"orientation"`
+	Mode              Mode           `json:"mode"`
+	GroundPlaneNormal mgl32.Vec3     `json:"groundPlaneNormal"`
+	IPD               float32        `json:"ipd"`
+	FocalLength       float32        `json:"focalLength"`
+	Near              float32        `json:"near"`
+	Far               float32        `json:"far"`
+	Screenheight      float32        `json:"screenHeight"`
+	Screenwidth       float32        `json:"screenWidth"`
+	Aperture          float32        `json:"aperture"`
+	FOV               float32        `json:"fov"`
+	Projection        ProjectionKind `json:"projection"`
+	OrthoHeight       float32        `json:"orthoHeight"`
+	MinZoomDistance   float32        `json:"minZoomDistance"`
+	MaxZoomDistance   float32        `json:"maxZoomDistance"`
+	Acceleration      float32        `json:"acceleration"`
+	TurnAcceleration  float32        `json:"turnAcceleration"`
+	MaxSpeed          float32        `json:"maxSpeed"`
+	MaxTurnRate       float32        `json:"maxTurnRate"`
+	Damping           float32        `json:"damping"`
+	AutoLevelRate     float32        `json:"autoLevelRate"`
+	FollowOffset      FollowOffset   `json:"followOffset"`
+	FollowLag         float32        `json:"followLag"`
+	FollowLookLag     float32        `json:"followLookLag"`
+	FollowLookAhead   float32        `json:"followLookAhead"`
+	Orbit             OrbitStyle     `json:"orbit"`
+	OrbitPitchLimit   float32        `json:"orbitPitchLimit"`
+	Stereo            StereoModel    `json:"stereo"`
+}
+
+// Snapshot returns a copy of the camera's state.
+func (c *Camera) Snapshot() CameraState {
+	return CameraState{
+		Version:           StateVersion,
+		Position:          c.Position,
+		Target:            c.Target,
+		Up:                c.Up,
+		Orientation:       c.Orientation,
+		Mode:              c.Mode,
+		GroundPlaneNormal: c.GroundPlaneNormal,
+		IPD:               c.IPD,
+		FocalLength:       c.FocalLength,
+		Near:              c.Near,
+		Far:               c.Far,
+		Screenheight:      c.Screenheight,
+		Screenwidth:       c.Screenwidth,
+		Aperture:          c.Aperture,
+		FOV:               c.FOV,
+		Projection:        c.Projection,
+		OrthoHeight:       c.OrthoHeight,
+		MinZoomDistance:   c.MinZoomDistance,
+		MaxZoomDistance:   c.MaxZoomDistance,
+		Acceleration:      c.Acceleration,
+		TurnAcceleration:  c.TurnAcceleration,
+		MaxSpeed:          c.MaxSpeed,
+		MaxTurnRate:       c.MaxTurnRate,
+		Damping:           c.Damping,
+		AutoLevelRate:     c.AutoLevelRate,
+		FollowOffset:      c.FollowOffset,
+		FollowLag:         c.FollowLag,
+		FollowLookLag:     c.FollowLookLag,
+		FollowLookAhead:   c.FollowLookAhead,
+		Orbit:             c.Orbit,
+		OrbitPitchLimit:   c.OrbitPitchLimit,
+		Stereo:            c.Stereo,
+	}
+}
+
+// Restore puts the camera back into a state returned by Snapshot.  Any movement or FlyTo in progress is stopped.
+// Unlike SetMode, it does not adjust the view for the new mode: the camera is exactly as it was saved.
+func (c *Camera) Restore(state CameraState) {
+	//The controller starts afresh, like the camera's motion
+	c.Mode = state.Mode
+	c.controller = nil
+	if c.cache == nil {
+		c.cache = &matrixCache{}
+	}
+	c.Position = state.Position
+	c.Target = state.Target
+	c.Up = state.Up
+	c.Orientation = state.Orientation
+	c.GroundPlaneNormal = state.GroundPlaneNormal
+	c.IPD = state.IPD
+	c.FocalLength = state.FocalLength
+	c.Near = state.Near
+	c.Far = state.Far
+	c.Screenheight = state.Screenheight
+	c.Screenwidth = state.Screenwidth
+	c.Aperture = state.Aperture
+	c.FOV = state.FOV
+	c.Projection = state.Projection
+	c.OrthoHeight = state.OrthoHeight
+	c.MinZoomDistance = state.MinZoomDistance
+	c.MaxZoomDistance = state.MaxZoomDistance
+	c.Acceleration = state.Acceleration
+	c.TurnAcceleration = state.TurnAcceleration
+	c.MaxSpeed = state.MaxSpeed
+	c.MaxTurnRate = state.MaxTurnRate
+	c.Damping = state.Damping
+	c.AutoLevelRate = state.AutoLevelRate
+	c.FollowOffset = state.FollowOffset
+	c.FollowLag = state.FollowLag
+	c.FollowLookLag = state.FollowLookLag
+	c.FollowLookAhead = state.FollowLookAhead
+	c.Orbit = state.Orbit
+	c.OrbitPitchLimit = state.OrbitPitchLimit
+	c.Stereo = state.Stereo
+	c.Halt()
+	c.EndGroundDrag()
+	c.CancelFlyTo()
+}
+
+// MarshalJSON encodes the camera's state as JSON.
+func (c *Camera) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Snapshot())
+}
+
+// UnmarshalJSON restores the camera from state encoded by MarshalJSON.
+func (c *Camera) UnmarshalJSON(data []byte) error {
+	var state CameraState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	if err := checkStateVersion(state.Version); err != nil {
+		return err
+	}
+	c.Restore(state)
+	return nil
+}
+
+func checkStateVersion(version int) error {
+	if version < 1 || version > StateVersion {
+		return fmt.Errorf("%w: %d", ErrStateVersion, version)
+	}
+	return nil
+}
+
+// binaryState is the layout of binary camera state, after the magic number and version.  All values are little-endian.
+type binaryState struct {
+	Position          mgl32.Vec3
+	Target            mgl32.Vec3
+	Up                mgl32.Vec3
+	Orientation       mgl32.Quat
+	Mode              int32
+	GroundPlaneNormal mgl32.Vec3
+	IPD               float32
+	FocalLength       float32
+	Near              float32
+	Far               float32
+	Screenheight      float32
+	Screenwidth       float32
+	Aperture          float32
+	FOV               float32
+	Projection        int32
+	OrthoHeight       float32
+	MinZoomDistance   float32
+	MaxZoomDistance   float32
+	Acceleration      float32
+	TurnAcceleration  float32
+	MaxSpeed          float32
+	MaxTurnRate       float32
+	Damping           float32
+	AutoLevelRate     float32
+	FollowOffset      FollowOffset
+	FollowLag         float32
+	FollowLookLag     float32
+	FollowLookAhead   float32
+	Orbit             int32
+	OrbitPitchLimit   float32
+	Stereo            int32
+}
+
+// MarshalBinary encodes the camera's state in a compact binary form: the four bytes "SCAM", a two byte version, then the fields of CameraState in order.
+func (c *Camera) MarshalBinary() ([]byte, error) {
+	state := c.Snapshot()
+	fields := binaryState{
+		Position:          state.Position,
+		Target:            state.Target,
+		Up:                state.Up,
+		Orientation:       state.Orientation,
+		Mode:              int32(state.Mode),
+		GroundPlaneNormal: state.GroundPlaneNormal,
+		IPD:               state.IPD,
+		FocalLength:       state.FocalLength,
+		Near:              state.Near,
+		Far:               state.Far,
+		Screenheight:      state.Screenheight,
+		Screenwidth:       state.Screenwidth,
+		Aperture:          state.Aperture,
+		FOV:               state.FOV,
+		Projection:        int32(state.Projection),
+		OrthoHeight:       state.OrthoHeight,
+		MinZoomDistance:   state.MinZoomDistance,
+		MaxZoomDistance:   state.MaxZoomDistance,
+		Acceleration:      state.Acceleration,
+		TurnAcceleration:  state.TurnAcceleration,
+		MaxSpeed:          state.MaxSpeed,
+		MaxTurnRate:       state.MaxTurnRate,
+		Damping:           state.Damping,
+		AutoLevelRate:     state.AutoLevelRate,
+		FollowOffset:      state.FollowOffset,
+		FollowLag:         state.FollowLag,
+		FollowLookLag:     state.FollowLookLag,
+		FollowLookAhead:   state.FollowLookAhead,
+		Orbit:             int32(state.Orbit),
+		OrbitPitchLimit:   state.OrbitPitchLimit,
+		Stereo:            int32(state.Stereo),
+	}
+
+	var buffer bytes.Buffer
+	buffer.Grow(len(stateMagic) + 2 + binary.Size(fields))
+	buffer.Write(stateMagic[:])
+	if err := binary.Write(&buffer, binary.LittleEndian, uint16(StateVersion)); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(&buffer, binary.LittleEndian, fields); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary restores the camera from state encoded by MarshalBinary.
+func (c *Camera) UnmarshalBinary(data []byte) error {
+	if len(data) < len(stateMagic)+2 || !bytes.Equal(data[:len(stateMagic)], stateMagic[:]) {
+		return fmt.Errorf("%w: missing header", ErrStateFormat)
+	}
+	version := int(binary.LittleEndian.Uint16(data[len(stateMagic):]))
+	if err := checkStateVersion(version); err != nil {
+		return err
+	}
+
+	var fields binaryState
+	body := data[len(stateMagic)+2:]
+	if len(body) != binary.Size(fields) {
+		return fmt.Errorf("%w: expected %d bytes of version %d state, got %d", ErrStateFormat, binary.Size(fields), version, len(body))
+	}
+	if err := binary.Read(bytes.NewReader(body), binary.LittleEndian, &fields); err != nil {
+		return fmt.Errorf("%w: %v", ErrStateFormat, err)
+	}
+
+	c.Restore(CameraState{
+		Version:           version,
+		Position:          fields.Position,
+		Target:            fields.Target,
+		Up:                fields.Up,
+		Orientation:       fields.Orientation,
+		Mode:              Mode(fields.Mode),
+		GroundPlaneNormal: fields.GroundPlaneNormal,
+		IPD:               fields.IPD,
+		FocalLength:       fields.FocalLength,
+		Near:              fields.Near,
+		Far:               fields.Far,
+		Screenheight:      fields.Screenheight,
+		Screenwidth:       fields.Screenwidth,
+		Aperture:          fields.Aperture,
+		FOV:               fields.FOV,
+		Projection:        ProjectionKind(fields.Projection),
+		OrthoHeight:       fields.OrthoHeight,
+		MinZoomDistance:   fields.MinZoomDistance,
+		MaxZoomDistance:   fields.MaxZoomDistance,
+		Acceleration:      fields.Acceleration,
+		TurnAcceleration:  fields.TurnAcceleration,
+		MaxSpeed:          fields.MaxSpeed,
+		MaxTurnRate:       fields.MaxTurnRate,
+		Damping:           fields.Damping,
+		AutoLevelRate:     fields.AutoLevelRate,
+		FollowOffset:      fields.FollowOffset,
+		FollowLag:         fields.FollowLag,
+		FollowLookLag:     fields.FollowLookLag,
+		FollowLookAhead:   fields.FollowLookAhead,
+		Orbit:             OrbitStyle(fields.Orbit),
+		OrbitPitchLimit:   fields.OrbitPitchLimit,
+		Stereo:            StereoModel(fields.Stereo),
+	})
+	return nil
+}
@@ -0,0 +1,233 @@
+package sceneCamera
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	camera := New(FPS)
+	camera.Restore(goldenState)
+	snapshot := camera.Snapshot()
+	if snapshot.Version != StateVersion {
+		t.Errorf("expected version %d, got %d", StateVersion, snapshot.Version)
+	}
+
+	//Changing the camera must not change the snapshot
+	camera.SetMode(FPS)
+	camera.Move(Forward, 5)
+	camera.FOV = 0.3
+	camera.SetDesiredVelocity(YawLeft, 1)
+	camera.Update(0.1)
+	if snapshot.Mode != RTS || snapshot.FOV != 1.5 {
+		t.Fatalf("snapshot changed with the camera: %+v", snapshot)
+	}
+
+	camera.Restore(snapshot)
+	if camera.Snapshot() != snapshot {
+		t.Errorf("expected restored state %+v, got %+v", snapshot, camera.Snapshot())
+	}
+	if camera.Velocity(YawLeft) != 0 {
+		t.Errorf("expected restore to stop the camera, got velocity %v", camera.Velocity(YawLeft))
+	}
+	camera.Update(0.1)
+	if camera.Snapshot() != snapshot {
+		t.Errorf("expected the restored camera to stay still")
+	}
+}
+
+func TestStateEncodingRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		marshal   func(*Camera) ([]byte, error)
+		unmarshal func(*Camera, []byte) error
+	}{
+		{"json", func(c *Camera) ([]byte, error) { return json.Marshal(c) }, func(c *Camera, data []byte) error { return json.Unmarshal(data, c) }},
+		{"binary", (*Camera).MarshalBinary, (*Camera).UnmarshalBinary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			camera := New(FPS)
+			camera.Restore(goldenState)
+			data, err := tt.marshal(camera)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := New(Museum)
+			if err := tt.unmarshal(decoded, data); err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Snapshot() != camera.Snapshot() {
+				t.Errorf("expected %+v, got %+v", camera.Snapshot(), decoded.Snapshot())
+			}
+			assertMat4(t, decoded.ViewMatrix(), camera.ViewMatrix())
+			assertMat4(t, decoded.ProjectionMatrix(), camera.ProjectionMatrix())
+		})
+	}
+}
+
+func TestStateJSONFields(t *testing.T) {
+	camera := New(FPS)
+	camera.Restore(goldenState)
+	data, err := json.Marshal(camera)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"version":1`, `"mode":3`, `"ipd":0.0625`, `"screenWidth":1920`, `"groundPlaneNormal":[0,0,1]`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("expected %s in %s", field, data)
+		}
+	}
+}
+
+func TestStateVersionErrors(t *testing.T) {
+	camera := New(FPS)
+	before := camera.Snapshot()
+	for _, data := range []string{`{"mode":1}`, `{"version":99,"mode":1}`} {
+		if err := json.Unmarshal([]byte(data), camera); !errors.Is(err, ErrStateVersion) {
+			t.Errorf("expected ErrStateVersion for %s, got %v", data, err)
+		}
+	}
+
+	data, err := camera.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary.LittleEndian.PutUint16(data[4:], StateVersion+1)
+	if err := camera.UnmarshalBinary(data); !errors.Is(err, ErrStateVersion) {
+		t.Errorf("expected ErrStateVersion, got %v", err)
+	}
+	if camera.Snapshot() != before {
+		t.Errorf("expected a failed decode to leave the camera unchanged")
+	}
+}
+
+func TestStateBinaryFormatErrors(t *testing.T) {
+	camera := New(FPS)
+	data, err := camera.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data[:4]) != "SCAM" {
+		t.Errorf("expected the SCAM header, got %q", data[:4])
+	}
+
+	tests := map[string][]byte{
+		"empty":     nil,
+		"magic":     append([]byte("MACS"), data[4:]...),
+		"truncated": data[:len(data)-1],
+		"trailing":  append(append([]byte(nil), data...), 0),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := camera.UnmarshalBinary(data); !errors.Is(err, ErrStateFormat) {
+				t.Errorf("expected ErrStateFormat, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStateBinaryIsCompact(t *testing.T) {
+	camera := New(FPS)
+	camera.Restore(goldenState)
+	binaryData, err := camera.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := json.Marshal(camera)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//6 header bytes, 40 float32s and 4 int32s
+	if len(binaryData) != 6+44*4 {
+		t.Errorf("expected %d bytes, got %d", 6+44*4, len(binaryData))
+	}
+	if len(binaryData) >= len(jsonData) {
+		t.Errorf("expected binary (%d bytes) to be smaller than JSON (%d bytes)", len(binaryData), len(jsonData))
+	}
+}
+
+// goldenState is the camera state encoded in goldenBinary.  The values are exact in float32.
+var goldenState = CameraState{
+	Version:           StateVersion,
+	Position:          mgl32.Vec3{1, 2, 3},
+	Target:            mgl32.Vec3{4, 5, 6},
+	Up:                mgl32.Vec3{0, 0, 1},
+	Orientation:       mgl32.Quat{W: 0.5, V: mgl32.Vec3{0.5, 0.5, 0.5}},
+	Mode:              RTS,
+	GroundPlaneNormal: mgl32.Vec3{0, 0, 1},
+	IPD:               0.0625,
+	FocalLength:       4,
+	Near:              0.125,
+	Far:               512,
+	Screenheight:      1080,
+	Screenwidth:       1920,
+	Aperture:          2.5,
+	FOV:               1.5,
+	Projection:        ReversedZ,
+	OrthoHeight:       10,
+	MinZoomDistance:   2,
+	MaxZoomDistance:   80,
+	Acceleration:      8,
+	TurnAcceleration:  6,
+	MaxSpeed:          20,
+	MaxTurnRate:       3,
+	Damping:           4,
+	AutoLevelRate:     0.5,
+	FollowOffset:      FollowOffset{Distance: 4, Height: 1, Angle: 0.25},
+	FollowLag:         0.75,
+	FollowLookLag:     0.375,
+	FollowLookAhead:   1.25,
+	Orbit:             Trackball,
+	OrbitPitchLimit:   1.5,
+	Stereo:            ToeIn,
+}
+
+// goldenBinary is goldenState as written by version 1 of MarshalBinary.  If this test fails, the binary format has changed: bump StateVersion and keep reading this layout.
+const goldenBinary = "5343414d0100" + //"SCAM" and version 1
+	"0000803f0000004000004040000080400000a0400000c0400000000000000000" +
+	"0000803f0000003f0000003f0000003f0000003f030000000000000000000000" +
+	"0000803f0000803d000080400000003e00000044000087440000f04400002040" +
+	"0000c03f0200000000002041000000400000a042000000410000c0400000a041" +
+	"00004040000080400000003f000080400000803f0000803e0000403f0000c03e" +
+	"0000a03f020000000000c03f01000000"
+
+func TestStateBinaryGolden(t *testing.T) {
+	data, err := hex.DecodeString(goldenBinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	camera := New(FPS)
+	if err := camera.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if camera.Snapshot() != goldenState {
+		t.Errorf("expected %+v, got %+v", goldenState, camera.Snapshot())
+	}
+
+	encoded, err := camera.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("expected the golden encoding\n%s, got\n%s", goldenBinary, hex.EncodeToString(encoded))
+	}
+}
+
+func TestStateJSONMissingFields(t *testing.T) {
+	//Fields missing from the JSON are zero
+	decoded := New(FPS)
+	if err := json.Unmarshal([]byte(`{"version":1,"mode":2,"fov":1.5,"damping":4}`), decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Mode != FPS || decoded.FOV != 1.5 || decoded.Damping != 4 || decoded.AutoLevelRate != 0 {
+		t.Errorf("expected the JSON to decode, got %+v", decoded.Snapshot())
+	}
+}
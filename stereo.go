@@ -0,0 +1,90 @@
+package sceneCamera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// StereoModel selects how the left and right eye views are built from the camera, its IPD and its FocalLength.
+type StereoModel int
+
+// Stereo models.  The zero value is OffAxis.
+const (
+	// OffAxis keeps both eyes looking straight ahead, and shifts each eye's frustum towards the centre so they meet at FocalLength.
+	// Points at the focal distance have no disparity, and appear on the screen.  This is the standard model, and does not distort depth.
+	OffAxis StereoModel = iota
+	// ToeIn turns each eye inwards to look at the point FocalLength ahead of the camera.  It is simple, but the eyes' image planes are not parallel,
+	// so points away from the centre of the view have some vertical disparity.
+	ToeIn
+	// ParallelAxis keeps both eyes looking straight ahead with symmetric frusta.  Only points at infinity have no disparity, so everything appears in front of the screen.
+	ParallelAxis
+)
+
+// String returns the name of the stereo model.
+func (s StereoModel) String() string {
+	switch s {
+	case OffAxis:
+		return "OffAxis"
+	case ToeIn:
+		return "ToeIn"
+	case ParallelAxis:
+		return "ParallelAxis"
+	}
+	return fmt.Sprintf("StereoModel(%d)", int(s))
+}
+
+// SetStereoModel chooses how the eye view and frustum matrices are built.
+func (c *Camera) SetStereoModel(model StereoModel) {
+	c.Stereo = model
+}
+
+// eyeViewMatrix returns the view matrix for an eye offset along right by side times half the IPD: -1 for the left eye, 0 for mono, or 1 for the right eye.
+// forward, right and up are the camera's basis vectors.
+func (c *Camera) eyeViewMatrix(forward, right, up mgl32.Vec3, side float32) mgl32.Mat4 {
+	offset := side * c.IPD / 2
+	if c.Stereo == ToeIn && side != 0 {
+		//Turn the eye to look at the convergence point
+		eyepos := c.Position.Add(right.Mul(offset))
+		return mgl32.LookAtV(eyepos, c.Position.Add(forward.Mul(c.FocalLength)), up)
+	}
+	return c.offsetViewMatrix(right, offset)
+}
+
+// offsetViewMatrix returns the view matrix for a viewpoint moved offset along right, facing the same way as the camera.
+func (c *Camera) offsetViewMatrix(right mgl32.Vec3, offset float32) mgl32.Mat4 {
+	eyepos := c.Position.Add(right.Mul(offset))
+	rotation := c.Orientation.Mat4()
+	translation := mgl32.Translate3D(-eyepos.X(), -eyepos.Y(), -eyepos.Z())
+	return rotation.Mul4(translation)
+}
+
+// eyeFrustum returns the frustum matrix for one eye: side -1 for the left eye, or 1 for the right eye.
+// With OffAxis, the frustum is shifted towards the centre by the eye's offset scaled down to the near plane, so the two frusta cover the same rectangle at FocalLength.
+// The eye's viewport is half the width of the screen.
+func (c *Camera) eyeFrustum(side float32) mgl32.Mat4 {
+	return c.eyeFrustumAspect(side, c.Screenwidth/c.Screenheight/2)
+}
+
+// eyeFrustumAspect is eyeFrustum for an image with the given width to height ratio.
+func (c *Camera) eyeFrustumAspect(side, aspect_ratio float32) mgl32.Mat4 {
+	var offset float32
+	if c.Stereo == OffAxis {
+		offset = side * c.IPD / 2
+	}
+	return c.offsetFrustum(offset, aspect_ratio)
+}
+
+// offsetFrustum returns the frustum matrix for a viewpoint moved offset along the camera's right vector, sheared back towards the centre so that it covers the same rectangle at FocalLength as the camera does.
+func (c *Camera) offsetFrustum(offset, aspect_ratio float32) mgl32.Mat4 {
+	var frustumshift float32
+	if offset != 0 {
+		frustumshift = -offset * c.Near / c.FocalLength
+	}
+	top := c.Near * float32(math.Tan(float64(c.FOV/2)))
+	right := aspect_ratio*top + frustumshift
+	left := -aspect_ratio*top + frustumshift
+	bottom := -top
+	return mgl32.Frustum(left, right, bottom, top, c.Near, c.Far)
+}
@@ -0,0 +1,162 @@
+package sceneCamera
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// testStereoCamera returns a camera looking from position at target, with the focal plane at the target.
+func testStereoCamera(model StereoModel, position, target mgl32.Vec3) *Camera {
+	camera := New(FPS)
+	camera.Position = position
+	camera.LookAt(target.Elem())
+	camera.SetIPD(0.5)
+	camera.SetFocalLength(target.Sub(position).Len())
+	camera.Far = 1e5
+	camera.SetStereoModel(model)
+	return camera
+}
+
+// disparity returns how far a point appears to move, in pixels, between the left and right eye images.
+func disparity(t *testing.T, camera *Camera, p mgl32.Vec3) mgl32.Vec2 {
+	t.Helper()
+	//Both eyes cover the whole output in Anaglyph, so their pixel positions can be compared directly
+	left, leftVisible, leftErr := camera.EyeWorldToScreen(LeftEye, Anaglyph, p)
+	right, rightVisible, rightErr := camera.EyeWorldToScreen(RightEye, Anaglyph, p)
+	if leftErr != nil || rightErr != nil {
+		t.Fatal(leftErr, rightErr)
+	}
+	if !leftVisible || !rightVisible {
+		t.Fatalf("expected %v to be visible to both eyes", p)
+	}
+	return right.Sub(left)
+}
+
+func TestStereoModelStrings(t *testing.T) {
+	for model, expected := range map[StereoModel]string{OffAxis: "OffAxis", ToeIn: "ToeIn", ParallelAxis: "ParallelAxis", StereoModel(9): "StereoModel(9)"} {
+		if actual := model.String(); actual != expected {
+			t.Errorf("expected %q, got %q", expected, actual)
+		}
+	}
+}
+
+func TestOffAxisZeroDisparityAtFocalDistance(t *testing.T) {
+	poses := []struct {
+		name             string
+		position, target mgl32.Vec3
+	}{
+		{"straight", mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 0}},
+		{"diagonal", mgl32.Vec3{4, 3, 6}, mgl32.Vec3{-1, 1, 0}},
+	}
+	for _, pose := range poses {
+		t.Run(pose.name, func(t *testing.T) {
+			camera := testStereoCamera(OffAxis, pose.position, pose.target)
+			forward, right, up := camera.ForwardsVector(), camera.RightWardsVector(), camera.UpwardsVector()
+
+			//Every point on the focal plane appears in the same place to both eyes, not only the point in the middle
+			for _, offset := range []mgl32.Vec2{{0, 0}, {1, 0.5}, {-2, -1}, {1.5, -1.5}} {
+				p := pose.target.Add(right.Mul(offset.X())).Add(up.Mul(offset.Y()))
+				if d := disparity(t, camera, p); d.Len() > 1e-2 {
+					t.Errorf("expected no disparity at %v on the focal plane, got %v", offset, d)
+				}
+			}
+
+			//Nearer points cross over, further points do not, and neither moves vertically
+			near := disparity(t, camera, camera.Position.Add(forward.Mul(camera.FocalLength/2)))
+			far := disparity(t, camera, camera.Position.Add(forward.Mul(camera.FocalLength*2)))
+			if near.X() >= 0 || far.X() <= 0 {
+				t.Errorf("expected negative disparity in front of the focal plane and positive behind it, got %v and %v", near, far)
+			}
+			if math.Abs(float64(near.Y())) > 1e-2 || math.Abs(float64(far.Y())) > 1e-2 {
+				t.Errorf("expected no vertical disparity, got %v and %v", near, far)
+			}
+		})
+	}
+}
+
+func TestOffAxisFrustumShift(t *testing.T) {
+	camera := testStereoCamera(OffAxis, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 0})
+	camera.SetFocalLength(8)
+	//The frustum is shifted by (IPD/2) * Near / FocalLength, which moves its centre by that much at the near plane
+	shift := camera.IPD / 2 * camera.Near / camera.FocalLength
+	for _, eye := range []struct {
+		frustum mgl32.Mat4
+		side    float32
+	}{{camera.LeftEyeFrustum(), -1}, {camera.RightEyeFrustum(), 1}} {
+		centre := mgl32.TransformCoordinate(mgl32.Vec3{0, 0, -1}, eye.frustum.Inv())
+		if math.Abs(float64(centre.X()+eye.side*shift)) > 1e-6 {
+			t.Errorf("expected the near plane centre at %v, got %v", -eye.side*shift, centre.X())
+		}
+	}
+}
+
+func TestToeInConvergesAtFocalDistance(t *testing.T) {
+	camera := testStereoCamera(ToeIn, mgl32.Vec3{4, 3, 6}, mgl32.Vec3{-1, 1, 0})
+	if d := disparity(t, camera, mgl32.Vec3{-1, 1, 0}); d.Len() > 1e-2 {
+		t.Errorf("expected no disparity at the convergence point, got %v", d)
+	}
+
+	//The image planes are not parallel, so points in the corners of the view move vertically
+	corner := mgl32.Vec3{-1, 1, 0}.Add(camera.RightWardsVector().Mul(2)).Add(camera.UpwardsVector().Mul(2))
+	if d := disparity(t, camera, corner); math.Abs(float64(d.Y())) < 1e-2 {
+		t.Errorf("expected vertical disparity in the corner of the view, got %v", d)
+	}
+
+	//Both eyes still look along the camera's up vector
+	assertFiniteMat4(t, camera.LeftEyeViewMatrix())
+	leftUp := rigidInverse(camera.LeftEyeViewMatrix()).Col(1).Vec3()
+	if leftUp.Dot(camera.UpwardsVector()) < 0.99 {
+		t.Errorf("expected the left eye to stay upright, got up %v", leftUp)
+	}
+}
+
+func TestParallelAxisZeroDisparityAtInfinity(t *testing.T) {
+	camera := testStereoCamera(ParallelAxis, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 0})
+	assertMat4(t, camera.LeftEyeFrustum(), camera.RightEyeFrustum())
+
+	if d := disparity(t, camera, mgl32.Vec3{0, 0, -5e4}); d.Len() > 1e-1 {
+		t.Errorf("expected no disparity far away, got %v", d)
+	}
+	if d := disparity(t, camera, mgl32.Vec3{0, 0, 0}); d.X() >= 0 {
+		t.Errorf("expected points at the focal distance to appear in front of the screen, got %v", d)
+	}
+}
+
+func TestStereoModelChangesMatrices(t *testing.T) {
+	camera := testStereoCamera(OffAxis, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, 0})
+	offAxisView, offAxisFrustum := camera.LeftEyeViewMatrix(), camera.LeftEyeFrustum()
+
+	//The cache must notice the model change
+	camera.SetStereoModel(ToeIn)
+	if camera.LeftEyeViewMatrix().ApproxEqualThreshold(offAxisView, testEpsilon) {
+		t.Error("expected toe-in to turn the left eye")
+	}
+	if camera.LeftEyeFrustum().ApproxEqualThreshold(offAxisFrustum, testEpsilon) {
+		t.Error("expected toe-in to use a symmetric frustum")
+	}
+	assertMatchesUncached(t, camera)
+}
+
+func TestStereoFocalLengthChecks(t *testing.T) {
+	//Every model except ParallelAxis needs a focal length: OffAxis to shear the frusta, and ToeIn to aim the eyes
+	for model, needed := range map[StereoModel]bool{OffAxis: true, ToeIn: true, ParallelAxis: false} {
+		t.Run(model.String(), func(t *testing.T) {
+			camera := New(FPS)
+			camera.SetStereoModel(model)
+			camera.FocalLength = 0
+			_, err := camera.LeftEyeFrustumChecked()
+			if fields := invalidFields(t, err); needed != reflect.DeepEqual(fields, []string{"FocalLength"}) {
+				t.Errorf("expected FocalLength to be needed: %v, got errors for %v", needed, fields)
+			}
+			if needed {
+				assertPanics(t, func() { camera.RightEyeFrustum() })
+			} else {
+				assertFiniteMat4(t, camera.RightEyeFrustum())
+				assertFiniteMat4(t, camera.LeftEyeViewMatrix())
+			}
+		})
+	}
+}
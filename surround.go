@@ -0,0 +1,118 @@
+package sceneCamera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Display is one monitor of a Surround, measured in physical units such as centimetres.
+type Display struct {
+	Width  float32 //The width of the visible area
+	Height float32 //The height of the visible area
+	Bezel  float32 //The width of the frame on each side of the visible area
+	Angle  float32 //How far the display is turned to face right, in radians.  A display left of the center, angled in towards the viewer, has a positive angle
+}
+
+// Surround is a row of displays, joined frame to frame, that together show one wrap-around view.
+// The displays are centred vertically on the viewer's eye line.
+type Surround struct {
+	Displays []Display //From left to right
+	Center   int       //The index of the display straight ahead of the viewer
+	Distance float32   //How far the middle of the center display is from the viewer, in the same units as the displays
+}
+
+// validate checks that the layout can be built.
+func (s Surround) validate(v *validation) {
+	if len(s.Displays) == 0 {
+		v.add("Displays", "is empty")
+	} else if s.Center < 0 || s.Center >= len(s.Displays) {
+		v.add("Center", "is not a display (%d)", s.Center)
+	}
+	for i, display := range s.Displays {
+		field := fmt.Sprintf("Displays[%d]", i)
+		v.positive(field+".Width", display.Width)
+		v.positive(field+".Height", display.Height)
+		v.nonNegative(field+".Bezel", display.Bezel)
+		if !isFinite(display.Angle) || math.Abs(float64(display.Angle)) >= math.Pi/2 {
+			v.add(field+".Angle", "is not between -pi/2 and pi/2")
+		}
+	}
+	v.positive("Distance", s.Distance)
+}
+
+// SurroundScreens returns the visible area of each display in world space, in the same order as the displays.
+// The displays are placed around the camera as they are around the viewer, scaled so that the center display is FocalLength in front of the camera.
+// Objects at the focal distance straight ahead therefore appear on the center display, as they do with the eye frustums.
+//
+// It returns a *ValidationError if the layout or FocalLength is invalid.
+func (c *Camera) SurroundScreens(surround Surround) ([]Screen, error) {
+	var v validation
+	surround.validate(&v)
+	v.positive("FocalLength", c.FocalLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	//Lay the displays out in the viewer's space, with x to the right, y up and z back towards the viewer
+	type placed struct{ middle, right mgl32.Vec3 }
+	displays := make([]placed, len(surround.Displays))
+	halfFrame := func(i int) float32 {
+		return surround.Displays[i].Width/2 + surround.Displays[i].Bezel
+	}
+	for i, display := range surround.Displays {
+		sin, cos := math.Sincos(float64(display.Angle))
+		displays[i].right = mgl32.Vec3{float32(cos), 0, float32(-sin)}
+	}
+	displays[surround.Center].middle = mgl32.Vec3{0, 0, -surround.Distance}
+	//Each display hangs from the outer edge of the frame of the display next to it, working outwards from the center
+	for i := surround.Center + 1; i < len(displays); i++ {
+		edge := displays[i-1].middle.Add(displays[i-1].right.Mul(halfFrame(i - 1)))
+		displays[i].middle = edge.Add(displays[i].right.Mul(halfFrame(i)))
+	}
+	for i := surround.Center - 1; i >= 0; i-- {
+		edge := displays[i+1].middle.Sub(displays[i+1].right.Mul(halfFrame(i + 1)))
+		displays[i].middle = edge.Sub(displays[i].right.Mul(halfFrame(i)))
+	}
+
+	//Place the layout in front of the camera, scaled to the focal distance
+	forward, right, up := c.ForwardsVector(), c.RightWardsVector(), c.UpwardsVector()
+	scale := c.FocalLength / surround.Distance
+	toWorld := func(p mgl32.Vec3) mgl32.Vec3 {
+		return c.Position.Add(right.Mul(p.X() * scale)).Add(up.Mul(p.Y() * scale)).Sub(forward.Mul(p.Z() * scale))
+	}
+	screens := make([]Screen, len(displays))
+	for i, display := range surround.Displays {
+		across := displays[i].right.Mul(display.Width / 2)
+		upwards := mgl32.Vec3{0, display.Height / 2, 0}
+		middle := displays[i].middle
+		screens[i] = Screen{
+			LowerLeft:  toWorld(middle.Sub(across).Sub(upwards)),
+			LowerRight: toWorld(middle.Add(across).Sub(upwards)),
+			UpperLeft:  toWorld(middle.Sub(across).Add(upwards)),
+		}
+	}
+	return screens, nil
+}
+
+// SurroundMatrices returns the view and projection matrices for each display of the surround, as seen from the eye, in the same order as the displays.
+// Each display is a ScreenProjection of its place in SurroundScreens, so straight lines carry on straight across the bezels, and the part of the scene behind a bezel is hidden as it would be behind a window frame.
+// Use LeftEye and RightEye for stereo displays; the eyes are half the IPD either side of the camera.
+//
+// It returns a *ValidationError if the layout, FocalLength, Near or Far is invalid.
+func (c *Camera) SurroundMatrices(surround Surround, eye Eye) (views, projections []mgl32.Mat4, err error) {
+	screens, err := c.SurroundScreens(surround)
+	if err != nil {
+		return nil, nil, err
+	}
+	views = make([]mgl32.Mat4, len(screens))
+	projections = make([]mgl32.Mat4, len(screens))
+	for i, screen := range screens {
+		views[i], projections[i], err = c.ScreenProjection(screen, eye)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return views, projections, nil
+}
@@ -0,0 +1,153 @@
+package sceneCamera
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// testSurroundPose is a camera at (1, 2, 3) looking along -x.  The tests put the center display 7 world units away.
+var testSurroundPose = testPose{FPS, mgl32.Vec3{1, 2, 3}, mgl32.Vec3{-5, 2, 3}, mgl32.Vec3{0, 1, 0}}
+
+// testSurround is three 60 by 34 displays with 1.5 bezels, the outer two angled in by 30 degrees, 70 from the viewer.
+var testSurround = Surround{
+	Displays: []Display{
+		{Width: 60, Height: 34, Bezel: 1.5, Angle: math.Pi / 6},
+		{Width: 60, Height: 34, Bezel: 1.5},
+		{Width: 60, Height: 34, Bezel: 1.5, Angle: -math.Pi / 6},
+	},
+	Center:   1,
+	Distance: 70,
+}
+
+func TestSurroundScreensLayout(t *testing.T) {
+	camera := testSurroundPose.camera()
+	camera.SetFocalLength(7)
+	camera.SetIPD(0.065)
+	camera.Far = 1000
+	screens, err := camera.SurroundScreens(testSurround)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//The center display is square on, FocalLength ahead, and scaled from 70 to 7
+	assertVec3(t, screens[1].Center(), mgl32.Vec3{-6, 2, 3})
+	assertVec3(t, screens[1].LowerLeft, mgl32.Vec3{-6, 2 - 1.7, 3 + 3})
+	assertVec3(t, screens[1].LowerRight, mgl32.Vec3{-6, 2 - 1.7, 3 - 3})
+
+	//The frames touch, hinged by 30 degrees, so the visible areas are two bezels apart around the hinge
+	gap := float32(2 * 0.15 * math.Cos(math.Pi/12))
+	for _, pair := range [][2]mgl32.Vec3{{screens[0].LowerRight, screens[1].LowerLeft}, {screens[1].LowerRight, screens[2].LowerLeft}} {
+		if actual := pair[1].Sub(pair[0]).Len(); math.Abs(float64(actual-gap)) > 1e-4 {
+			t.Errorf("expected a gap of %v, got %v", gap, actual)
+		}
+	}
+
+	//The side displays are turned in towards the camera by their angles
+	for i, side := range map[int]float32{0: 1, 2: -1} {
+		_, _, normal := screens[i].axes()
+		assertVec3(t, normal, camera.ForwardsVector().Mul(-float32(math.Cos(math.Pi/6))).Add(camera.RightWardsVector().Mul(side*0.5)))
+	}
+}
+
+func TestSurroundLinesCrossBezels(t *testing.T) {
+	camera := testSurroundPose.camera()
+	camera.SetFocalLength(7)
+	camera.SetIPD(0.065)
+	camera.Far = 1000
+	screens, err := camera.SurroundScreens(testSurround)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, eye := range []Eye{MonoEye, LeftEye, RightEye} {
+		t.Run(eye.String(), func(t *testing.T) {
+			views, projections, err := camera.SurroundMatrices(testSurround, eye)
+			if err != nil {
+				t.Fatal(err)
+			}
+			position := camera.ScreenEyePosition(eye)
+			//Sweep along a straight line in the world that runs across all three displays
+			var drawn [3]int
+			for step := 0; step <= 200; step++ {
+				s := float32(step) / 200
+				p := mgl32.Vec3{-20, 1 + s, 3 + 30 - 60*s}
+				for i, screen := range screens {
+					ndc := mgl32.TransformCoordinate(p, projections[i].Mul4(views[i]))
+					if ndc.X() < -1 || ndc.X() > 1 || ndc.Y() < -1 || ndc.Y() > 1 {
+						continue
+					}
+					drawn[i]++
+					//Where the point is drawn on the physical display must be on the line of sight to it
+					right, up, _ := screen.axes()
+					width := screen.LowerRight.Sub(screen.LowerLeft).Len()
+					height := screen.UpperLeft.Sub(screen.LowerLeft).Len()
+					onDisplay := screen.LowerLeft.Add(right.Mul(width * (ndc.X() + 1) / 2)).Add(up.Mul(height * (ndc.Y() + 1) / 2))
+					sight := p.Sub(position).Normalize()
+					if miss := sight.Cross(onDisplay.Sub(position).Normalize()).Len(); miss > 1e-4 {
+						t.Errorf("display %d draws %v off the line of sight by %v", i, p, miss)
+					}
+				}
+			}
+			for i, count := range drawn {
+				if count == 0 {
+					t.Errorf("expected the line to cross display %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestSurroundHidesBezels(t *testing.T) {
+	camera := testSurroundPose.camera()
+	camera.SetFocalLength(7)
+	camera.SetIPD(0.065)
+	camera.Far = 1000
+	screens, _ := camera.SurroundScreens(testSurround)
+	views, projections, err := camera.SurroundMatrices(testSurround, MonoEye)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//A point seen through the middle of the bezel between the center and right displays is on neither
+	bezel := screens[1].LowerRight.Add(screens[2].LowerLeft).Mul(0.5).Add(camera.UpwardsVector())
+	p := camera.Position.Add(bezel.Sub(camera.Position).Mul(3))
+	for i := range screens {
+		ndc := mgl32.TransformCoordinate(p, projections[i].Mul4(views[i]))
+		if ndc.X() >= -1 && ndc.X() <= 1 && ndc.Y() >= -1 && ndc.Y() <= 1 {
+			t.Errorf("expected the point behind the bezel to be hidden, but display %d shows it at %v", i, ndc)
+		}
+	}
+}
+
+func TestSurroundSingleDisplayMatchesPerspective(t *testing.T) {
+	camera := New(FPS)
+	camera.SetFocalLength(5)
+	surround := Surround{Displays: []Display{{Width: 16, Height: 9}}, Distance: 8}
+	views, projections, err := camera.SurroundMatrices(surround, MonoEye)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fov := float32(2 * math.Atan(4.5/8))
+	assertMat4(t, projections[0], mgl32.Perspective(fov, 16.0/9, camera.Near, camera.Far))
+	assertMat4(t, views[0], camera.ViewMatrix())
+}
+
+func TestSurroundErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		surround Surround
+		expected []string
+	}{
+		{"empty", Surround{Distance: 1}, []string{"Displays"}},
+		{"center", Surround{Displays: []Display{{Width: 1, Height: 1}}, Center: 1, Distance: 1}, []string{"Center"}},
+		{"display", Surround{Displays: []Display{{Width: 1, Height: 1}, {Height: 1, Bezel: -1, Angle: 2}}, Distance: 0}, []string{"Displays[1].Width", "Displays[1].Bezel", "Displays[1].Angle", "Distance"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, _, err := New(FPS).SurroundMatrices(testCase.surround, MonoEye)
+			if fields := invalidFields(t, err); !reflect.DeepEqual(fields, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Easing shapes the progress of a transition.  It maps the fraction of the time gone, from 0 to 1, to the fraction of the distance covered.
+type Easing func(u float32) float32
+
+// EaseLinear moves at a constant speed, starting and stopping abruptly.
+func EaseLinear(u float32) float32 {
+	return u
+}
+
+// EaseIn starts slowly and speeds up.
+func EaseIn(u float32) float32 {
+	return u * u
+}
+
+// EaseOut starts quickly and slows down as it arrives.
+func EaseOut(u float32) float32 {
+	return u * (2 - u)
+}
+
+// EaseInOut starts and stops gently.  This is smoothstep, and is the easing used when FlyTo is given nil.
+func EaseInOut(u float32) float32 {
+	return u * u * (3 - 2*u)
+}
+
+// transition is a FlyTo in progress.
+type transition struct {
+	active   bool
+	path     *CameraPath //From the start pose at time 0 to the end pose at time 1
+	end      Pose
+	elapsed  float32
+	duration float32
+	easing   Easing
+}
+
+// FlyTo moves the camera smoothly to a bookmark over duration seconds, as Update is called.
+// The position moves in a straight line, the view turns along the shortest arc, and the FOV and target distance change evenly, all following the easing curve.  A nil easing uses EaseInOut.
+//
+// While the camera is flying, Update does not apply desired velocities.  Any current movement is stopped.  A duration of zero or less moves the camera immediately.
+func (c *Camera) FlyTo(bookmark Bookmark, duration float32, easing Easing) {
+	c.Halt()
+	if duration <= 0 {
+		c.transition = transition{}
+		c.SetPose(bookmark.Pose)
+		return
+	}
+	if easing == nil {
+		easing = EaseInOut
+	}
+	path := NewCameraPath(
+		Keyframe{Time: 0, Pose: c.Pose()},
+		Keyframe{Time: 1, Pose: bookmark.Pose},
+	)
+	path.Interpolation = Linear
+	path.Rotation = Slerp
+	c.transition = transition{
+		active:   true,
+		path:     path,
+		end:      bookmark.Pose,
+		duration: duration,
+		easing:   easing,
+	}
+}
+
+// Flying returns true while a FlyTo is in progress.
+func (c *Camera) Flying() bool {
+	return c.transition.active
+}
+
+// CancelFlyTo stops a FlyTo where it is.
+func (c *Camera) CancelFlyTo() {
+	c.transition = transition{}
+}
+
+// updateTransition moves a FlyTo on by dt seconds.
+func (c *Camera) updateTransition(dt float32) {
+	t := &c.transition
+	t.elapsed += dt
+	if t.elapsed >= t.duration {
+		end := t.end
+		c.transition = transition{}
+		c.SetPose(end)
+		return
+	}
+	u := t.easing(mgl32.Clamp(t.elapsed/t.duration, 0, 1))
+	t.path.Sample(u, c)
+}
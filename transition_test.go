@@ -0,0 +1,105 @@
+package sceneCamera
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func testExhibit() Bookmark {
+	return Bookmark{Name: "exhibit", Pose: Pose{
+		Position: mgl32.Vec3{10, 0, 2},
+		Target:   mgl32.Vec3{10, 6, 2},
+		Up:       mgl32.Vec3{0, 0, 1},
+		FOV:      0.5,
+	}}
+}
+
+func TestEasing(t *testing.T) {
+	for name, easing := range map[string]Easing{"linear": EaseLinear, "in": EaseIn, "out": EaseOut, "inOut": EaseInOut} {
+		t.Run(name, func(t *testing.T) {
+			assertFloat(t, easing(0), 0)
+			assertFloat(t, easing(1), 1)
+			previous := float32(0)
+			for i := 1; i <= 10; i++ {
+				value := easing(float32(i) / 10)
+				if value < previous {
+					t.Errorf("expected easing to never go backwards, got %v after %v", value, previous)
+				}
+				previous = value
+			}
+		})
+	}
+	assertFloat(t, EaseInOut(0.5), 0.5)
+}
+
+func TestFlyTo(t *testing.T) {
+	camera := New(Museum)
+	start := camera.Pose()
+	exhibit := testExhibit()
+	camera.SetDesiredVelocity(Forward, 3)
+	camera.FlyTo(exhibit, 2, EaseLinear)
+	if !camera.Flying() {
+		t.Fatalf("expected the camera to be flying")
+	}
+
+	//Halfway through a linear flight the camera is halfway there
+	camera.Update(1)
+	assertVec3(t, camera.Position, start.Position.Add(exhibit.Position).Mul(0.5))
+	assertFloat(t, camera.FOV, (start.FOV+exhibit.FOV)/2)
+	if camera.Velocity(Forward) != 0 {
+		t.Errorf("expected FlyTo to stop other movement, got velocity %v", camera.Velocity(Forward))
+	}
+
+	//Overshooting the duration lands exactly on the bookmark
+	camera.Update(1.5)
+	if camera.Flying() {
+		t.Errorf("expected the flight to be over")
+	}
+	assertVec3(t, camera.Position, exhibit.Position)
+	assertVec3(t, camera.Target, exhibit.Target)
+	assertVec3(t, camera.UpwardsVector(), exhibit.Up)
+	if !camera.Orientation.OrientationEqualThreshold(exhibit.Orientation(), 1e-4) {
+		t.Errorf("expected orientation %v, got %v", exhibit.Orientation(), camera.Orientation)
+	}
+}
+
+func TestFlyToIsSmooth(t *testing.T) {
+	camera := New(Museum)
+	exhibit := testExhibit()
+	camera.FlyTo(exhibit, 1, nil)
+	const steps = 100
+	previous := camera.Position
+	var largest float32
+	for i := 0; i < steps; i++ {
+		camera.Update(1.0 / steps)
+		largest = max(largest, camera.Position.Sub(previous).Len())
+		previous = camera.Position
+	}
+	//EaseInOut peaks at 1.5 times the average speed
+	distance := exhibit.Position.Sub(New(Museum).Position).Len()
+	if limit := 1.5 * distance / steps * 1.01; largest > limit {
+		t.Errorf("expected no step larger than %v, got %v", limit, largest)
+	}
+	assertVec3(t, camera.Position, exhibit.Position)
+}
+
+func TestFlyToImmediateAndCancel(t *testing.T) {
+	camera := New(FPS)
+	exhibit := testExhibit()
+	camera.FlyTo(exhibit, 0, nil)
+	if camera.Flying() {
+		t.Errorf("expected a zero duration to move immediately")
+	}
+	assertVec3(t, camera.Position, exhibit.Position)
+
+	camera.Reset()
+	camera.FlyTo(exhibit, 4, EaseIn)
+	camera.Update(1)
+	camera.CancelFlyTo()
+	stopped := camera.Position
+	camera.Update(1)
+	if camera.Flying() || camera.Position != stopped {
+		t.Errorf("expected a cancelled flight to stay put, moved from %v to %v", stopped, camera.Position)
+	}
+}
@@ -0,0 +1,248 @@
+package sceneCamera
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// ErrInvalidCamera is matched, with errors.Is, by the errors from Validate and the checked projection constructors.
+var ErrInvalidCamera = errors.New("invalid camera")
+
+// FieldError describes one invalid Camera field.
+type FieldError struct {
+	Field   string //The name of the Camera field
+	Problem string //What is wrong with it, such as "is zero"
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.Problem
+}
+
+// Unwrap returns ErrInvalidCamera.
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidCamera
+}
+
+// ValidationError lists every invalid field found by a check.  Use errors.As to find it, or a FieldError within it.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		problems[i] = field.Error()
+	}
+	return "invalid camera: " + strings.Join(problems, "; ")
+}
+
+// Unwrap returns each FieldError, so errors.Is and errors.As see them all.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// validation collects field problems for a ValidationError.
+type validation struct {
+	fields []*FieldError
+}
+
+func (v *validation) add(field, format string, args ...any) {
+	v.fields = append(v.fields, &FieldError{Field: field, Problem: fmt.Sprintf(format, args...)})
+}
+
+// err returns a ValidationError, or nil if no problems were found.
+func (v *validation) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+// positive reports a field that is zero, negative or not finite.
+func (v *validation) positive(field string, value float32) {
+	switch {
+	case !isFinite(value):
+		v.add(field, "is not finite")
+	case value == 0:
+		v.add(field, "is zero")
+	case value < 0:
+		v.add(field, "is negative")
+	}
+}
+
+// nonNegative reports a field that is negative or not finite.
+func (v *validation) nonNegative(field string, value float32) {
+	switch {
+	case !isFinite(value):
+		v.add(field, "is not finite")
+	case value < 0:
+		v.add(field, "is negative")
+	}
+}
+
+// direction reports a vector that is zero or not finite.
+func (v *validation) direction(field string, value mgl32.Vec3) {
+	switch {
+	case !isFiniteVec3(value):
+		v.add(field, "is not finite")
+	case value.Len() == 0:
+		v.add(field, "is zero")
+	}
+}
+
+func isFinite(value float32) bool {
+	return !math.IsNaN(float64(value)) && !math.IsInf(float64(value), 0)
+}
+
+func isFiniteVec3(value mgl32.Vec3) bool {
+	return isFinite(value.X()) && isFinite(value.Y()) && isFinite(value.Z())
+}
+
+// Validate checks the camera for fields that would give broken matrices, or make the camera misbehave.
+// It reports every invalid field in a *ValidationError, or returns nil if the camera is usable.
+func (c *Camera) Validate() error {
+	var v validation
+	if !isFiniteVec3(c.Position) {
+		v.add("Position", "is not finite")
+	}
+	if !isFiniteVec3(c.Target) {
+		v.add("Target", "is not finite")
+	} else if c.Target.Sub(c.Position).Len() < MinTargetDistance {
+		v.add("Target", "is closer to Position than MinTargetDistance")
+	}
+	v.direction("Up", c.Up)
+	v.direction("GroundPlaneNormal", c.GroundPlaneNormal)
+	orientation := c.Orientation
+	if !isFinite(orientation.W) || !isFiniteVec3(orientation.V) {
+		v.add("Orientation", "is not finite")
+	} else if length := orientation.Len(); math.Abs(float64(length-1)) > 1e-3 {
+		v.add("Orientation", "is not a unit quaternion (length %g)", length)
+	}
+	if _, ok := lookupMode(c.Mode); !ok {
+		v.add("Mode", "is not a registered mode (%d)", int(c.Mode))
+	}
+	c.validateProjection(&v)
+	if c.Stereo < OffAxis || c.Stereo > ParallelAxis {
+		v.add("Stereo", "is not a stereo model (%d)", int(c.Stereo))
+	}
+	v.nonNegative("IPD", c.IPD)
+	v.positive("FocalLength", c.FocalLength)
+	v.nonNegative("Aperture", c.Aperture)
+	v.nonNegative("MinZoomDistance", c.MinZoomDistance)
+	v.nonNegative("MaxZoomDistance", c.MaxZoomDistance)
+	if c.MaxZoomDistance > 0 && c.MaxZoomDistance < c.MinZoomDistance {
+		v.add("MaxZoomDistance", "is less than MinZoomDistance")
+	}
+	for _, tuning := range []struct {
+		field string
+		value float32
+	}{
+		{"Acceleration", c.Acceleration},
+		{"TurnAcceleration", c.TurnAcceleration},
+		{"MaxSpeed", c.MaxSpeed},
+		{"MaxTurnRate", c.MaxTurnRate},
+		{"Damping", c.Damping},
+		{"AutoLevelRate", c.AutoLevelRate},
+		{"FollowLag", c.FollowLag},
+		{"FollowLookLag", c.FollowLookLag},
+		{"OrbitPitchLimit", c.OrbitPitchLimit},
+	} {
+		v.nonNegative(tuning.field, tuning.value)
+	}
+	return v.err()
+}
+
+// validateProjection checks the fields that ProjectionMatrix uses.
+func (c *Camera) validateProjection(v *validation) {
+	v.positive("Screenwidth", c.Screenwidth)
+	v.positive("Screenheight", c.Screenheight)
+	switch c.Projection {
+	case Orthographic:
+		v.positive("OrthoHeight", c.OrthoHeight)
+		if !isFinite(c.Near) {
+			v.add("Near", "is not finite")
+		}
+	default:
+		v.positive("Near", c.Near)
+	}
+	if c.FOV >= math.Pi {
+		v.add("FOV", "is not less than pi")
+	} else {
+		v.positive("FOV", c.FOV)
+	}
+	if c.Projection == InfiniteFar || c.Projection == ReversedZInfiniteFar {
+		//Far is ignored
+		return
+	}
+	if !isFinite(c.Far) {
+		v.add("Far", "is not finite")
+	} else if c.Far <= c.Near {
+		v.add("Far", "is not greater than Near")
+	}
+}
+
+// ProjectionMatrixChecked is ProjectionMatrix, but returns a *ValidationError instead of a broken matrix if the fields it uses are invalid.
+// This happens, for example, when a minimised window has a zero size.
+func (c *Camera) ProjectionMatrixChecked() (mgl32.Mat4, error) {
+	var v validation
+	c.validateProjection(&v)
+	if err := v.err(); err != nil {
+		return mgl32.Mat4{}, err
+	}
+	return c.ProjectionMatrix(), nil
+}
+
+// LeftEyeFrustumChecked is LeftEyeFrustum, but returns a *ValidationError instead of panicking if the fields it uses are invalid.
+func (c *Camera) LeftEyeFrustumChecked() (mgl32.Mat4, error) {
+	if err := c.validateEyeFrustum(); err != nil {
+		return mgl32.Mat4{}, err
+	}
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.projectionCache(cache, LeftEye).projection, nil
+}
+
+// RightEyeFrustumChecked is RightEyeFrustum, but returns a *ValidationError instead of panicking if the fields it uses are invalid.
+func (c *Camera) RightEyeFrustumChecked() (mgl32.Mat4, error) {
+	if err := c.validateEyeFrustum(); err != nil {
+		return mgl32.Mat4{}, err
+	}
+	cache := c.lockCache()
+	defer cache.Unlock()
+	return c.projectionCache(cache, RightEye).projection, nil
+}
+
+// validateEyeFrustum checks the fields that the eye frustums use.
+func (c *Camera) validateEyeFrustum() error {
+	var v validation
+	v.positive("IPD", c.IPD)
+	if c.Stereo != ParallelAxis {
+		v.positive("FocalLength", c.FocalLength)
+	}
+	c.validateOffsetFrustum(&v)
+	return v.err()
+}
+
+// validateOffsetFrustum checks the fields that offsetFrustum uses, apart from FocalLength.
+func (c *Camera) validateOffsetFrustum(v *validation) {
+	v.positive("Screenwidth", c.Screenwidth)
+	v.positive("Screenheight", c.Screenheight)
+	v.positive("Near", c.Near)
+	v.positive("FOV", c.FOV)
+	if c.FOV >= math.Pi {
+		v.add("FOV", "is not less than pi")
+	}
+	if !isFinite(c.Far) {
+		v.add("Far", "is not finite")
+	} else if c.Far <= c.Near {
+		v.add("Far", "is not greater than Near")
+	}
+}
@@ -0,0 +1,132 @@
+package sceneCamera
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// invalidFields returns the names of the fields reported by a Validate error.
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrInvalidCamera) {
+		t.Errorf("expected ErrInvalidCamera, got %v", err)
+	}
+	var validationError *ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("expected a *ValidationError, got %T", err)
+	}
+	var fields []string
+	for _, field := range validationError.Fields {
+		fields = append(fields, field.Field)
+	}
+	return fields
+}
+
+func TestNewCamerasAreValid(t *testing.T) {
+	for _, mode := range []Mode{Museum, FPS, RTS, Flight, Follow} {
+		t.Run(mode.String(), func(t *testing.T) {
+			if err := New(mode).Validate(); err != nil {
+				t.Errorf("expected a valid camera, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	nan := float32(math.NaN())
+	testCases := []struct {
+		name     string
+		change   func(c *Camera)
+		expected []string
+	}{
+		{"minimised", func(c *Camera) { c.Screenwidth, c.Screenheight = 0, 0 }, []string{"Screenwidth", "Screenheight"}},
+		{"near past far", func(c *Camera) { c.Near, c.Far = 10, 5 }, []string{"Far"}},
+		{"near equals far", func(c *Camera) { c.Near, c.Far = 5, 5 }, []string{"Far"}},
+		{"zero near", func(c *Camera) { c.Near = 0 }, []string{"Near"}},
+		{"infinite far ignores far", func(c *Camera) { c.Projection, c.Far = InfiniteFar, 0 }, nil},
+		{"orthographic allows zero near", func(c *Camera) { c.Projection, c.Near = Orthographic, 0 }, nil},
+		{"orthographic height", func(c *Camera) { c.Projection, c.OrthoHeight = Orthographic, 0 }, []string{"OrthoHeight"}},
+		{"fov", func(c *Camera) { c.FOV = 4 }, []string{"FOV"}},
+		{"non-unit orientation", func(c *Camera) { c.Orientation = c.Orientation.Scale(2) }, []string{"Orientation"}},
+		{"nan orientation", func(c *Camera) { c.Orientation.W = nan }, []string{"Orientation"}},
+		{"nan position", func(c *Camera) { c.Position[0] = nan }, []string{"Position"}},
+		{"target on position", func(c *Camera) { c.Target = c.Position }, []string{"Target"}},
+		{"zero vectors", func(c *Camera) { c.Up, c.GroundPlaneNormal = mgl32.Vec3{}, mgl32.Vec3{} }, []string{"Up", "GroundPlaneNormal"}},
+		{"unknown mode", func(c *Camera) { c.Mode = Mode(0) }, []string{"Mode"}},
+		{"unknown stereo model", func(c *Camera) { c.Stereo = StereoModel(7) }, []string{"Stereo"}},
+		{"zoom limits", func(c *Camera) { c.MinZoomDistance, c.MaxZoomDistance = 5, 2 }, []string{"MaxZoomDistance"}},
+		{"negative tuning", func(c *Camera) { c.Damping, c.FollowLag = -1, -1 }, []string{"Damping", "FollowLag"}},
+		{"everything at once", func(c *Camera) { c.Screenheight, c.IPD, c.FocalLength = 0, -1, 0 }, []string{"Screenheight", "IPD", "FocalLength"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			camera := New(Museum)
+			testCase.change(camera)
+			fields := invalidFields(t, camera.Validate())
+			if !reflect.DeepEqual(fields, testCase.expected) {
+				t.Errorf("expected invalid fields %v, got %v", testCase.expected, fields)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	camera := New(Museum)
+	camera.Screenheight = 0
+	camera.Near, camera.Far = 10, 5
+	expected := "invalid camera: Screenheight is zero; Far is not greater than Near"
+	if err := camera.Validate(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+	var fieldError *FieldError
+	if !errors.As(camera.Validate(), &fieldError) || fieldError.Field != "Screenheight" {
+		t.Errorf("expected to find the first FieldError, got %v", fieldError)
+	}
+}
+
+func TestCheckedProjections(t *testing.T) {
+	camera := New(Museum)
+	constructors := []struct {
+		name      string
+		checked   func() (mgl32.Mat4, error)
+		unchecked func() mgl32.Mat4
+	}{
+		{"projection", camera.ProjectionMatrixChecked, camera.ProjectionMatrix},
+		{"left eye", camera.LeftEyeFrustumChecked, camera.LeftEyeFrustum},
+		{"right eye", camera.RightEyeFrustumChecked, camera.RightEyeFrustum},
+	}
+	for _, constructor := range constructors {
+		t.Run(constructor.name, func(t *testing.T) {
+			camera.Screenwidth, camera.Screenheight = 1920, 1080
+			matrix, err := constructor.checked()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			assertMat4(t, matrix, constructor.unchecked())
+
+			//A minimised window
+			camera.Screenwidth, camera.Screenheight = 0, 0
+			_, err = constructor.checked()
+			if fields := invalidFields(t, err); !reflect.DeepEqual(fields, []string{"Screenwidth", "Screenheight"}) {
+				t.Errorf("expected the screen size to be reported, got %v", fields)
+			}
+		})
+	}
+
+	//Only the eye frustums need an eye separation
+	camera.Screenwidth, camera.Screenheight = 1920, 1080
+	camera.IPD = 0
+	if _, err := camera.ProjectionMatrixChecked(); err != nil {
+		t.Errorf("expected no error without IPD, got %v", err)
+	}
+	if _, err := camera.LeftEyeFrustumChecked(); err == nil {
+		t.Errorf("expected an error without IPD")
+	}
+}
@@ -0,0 +1,69 @@
+package sceneCamera
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// SetZoomLimits sets the closest and furthest distances that ZoomToCursor will move the camera to, from the point under the cursor.
+// A maximum of zero means there is no upper limit.
+func (c *Camera) SetZoomLimits(minDistance, maxDistance float32) {
+	c.MinZoomDistance = minDistance
+	c.MaxZoomDistance = maxDistance
+}
+
+// ZoomPointAt returns the world point that ZoomToCursor moves towards for a pixel, measured from the top left of the screen.
+//
+// In RTS mode this is the point on the ground plane.  In museum mode it is the point on the plane through the target, facing the camera.
+// ok is false in other modes, or when the pixel does not reach the plane.
+func (c *Camera) ZoomPointAt(x, y float32) (point mgl32.Vec3, ok bool) {
+	switch c.Mode {
+	case RTS:
+		return c.GroundPointAt(x, y)
+	case Museum:
+		origin, direction := c.ScreenRay(x, y)
+		return PlaneIntercept2Checked(c.Target, c.ForwardsVector(), origin, direction)
+	}
+	return mgl32.Vec3{}, false
+}
+
+// ZoomToCursor zooms towards the world point under a pixel, keeping that point at the same place on screen.
+// Positive amounts zoom in, and negative amounts zoom out, in world units.  Zooming in stops at MinZoomDistance from the point, and never closer than Near, and zooming out stops at MaxZoomDistance.
+// A camera that is already past a limit stays where it is, rather than being pushed back to it.
+//
+// The camera is moved without rotating, so the target slides sideways with it.  Orthographic cameras scale OrthoHeight to match.
+// It returns false, and leaves the camera unchanged, if there is no point under the pixel.  See ZoomPointAt.
+func (c *Camera) ZoomToCursor(x, y, amount float32) bool {
+	point, ok := c.ZoomPointAt(x, y)
+	if !ok {
+		return false
+	}
+	toPoint := point.Sub(c.Position)
+	distance := toPoint.Len()
+	if distance == 0 {
+		return false
+	}
+
+	//Only clamp in the direction of motion, so a camera already past a limit never jumps back to it
+	newDistance := distance - amount
+	if amount > 0 {
+		newDistance = max(newDistance, min(max(c.MinZoomDistance, c.Near), distance))
+	} else if c.MaxZoomDistance > 0 {
+		newDistance = min(newDistance, max(c.MaxZoomDistance, distance))
+	}
+
+	//Moving along the line to the point keeps it on the same pixel.
+	//For an orthographic camera the same move, combined with scaling the view volume, has the same effect.
+	offset := toPoint.Mul(1 - newDistance/distance)
+	forward := c.ForwardsVector()
+	c.Position = c.Position.Add(offset)
+	c.scaleOrthoHeight(distance, newDistance)
+
+	//Slide the target sideways with the camera, so the view does not rotate, but keep it at the same depth so that museum mode orbits the same plane
+	c.Target = c.Target.Add(offset.Sub(forward.Mul(offset.Dot(forward))))
+
+	if c.Mode == RTS {
+		//Keep the target on the ground, as RTS movement expects
+		if target, ok := PlaneInterceptChecked(c.GroundPlaneNormal, c.Position, c.ForwardsVector()); ok {
+			c.Target = target
+		}
+	}
+	return true
+}
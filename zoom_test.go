@@ -0,0 +1,90 @@
+package sceneCamera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestZoomToCursorKeepsPointUnderCursor(t *testing.T) {
+	for _, mode := range []Mode{Museum, RTS} {
+		for _, kind := range []ProjectionKind{Perspective, Orthographic} {
+			t.Run(mode.String()+"-"+kind.String(), func(t *testing.T) {
+				camera := New(mode)
+				camera.SetProjection(kind)
+				pixel := mgl32.Vec2{1300, 800}
+				point, ok := camera.ZoomPointAt(pixel.X(), pixel.Y())
+				if !ok {
+					t.Fatal("expected a point under the cursor")
+				}
+				before := camera.Position.Sub(point).Len()
+
+				for _, amount := range []float32{1, 0.5, -2} {
+					if !camera.ZoomToCursor(pixel.X(), pixel.Y(), amount) {
+						t.Fatal("expected the zoom to succeed")
+					}
+					screen, visible := camera.WorldToScreen(point)
+					if !visible {
+						t.Errorf("expected %v to stay visible", point)
+					}
+					assertVec2(t, screen, pixel)
+				}
+				after := camera.Position.Sub(point).Len()
+				if math.Abs(float64(after-before-0.5)) > 1e-3 {
+					t.Errorf("expected a net zoom out of 0.5, distance went from %v to %v", before, after)
+				}
+			})
+		}
+	}
+}
+
+func TestZoomToCursorLimits(t *testing.T) {
+	camera := New(Museum)
+	camera.SetZoomLimits(2, 8)
+	centre := mgl32.Vec2{camera.Screenwidth / 2, camera.Screenheight / 2}
+
+	camera.ZoomToCursor(centre.X(), centre.Y(), 100)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 0, 2})
+	camera.ZoomToCursor(centre.X(), centre.Y(), -100)
+	assertVec3(t, camera.Position, mgl32.Vec3{0, 0, 8})
+}
+
+func TestZoomToCursorInsideLimits(t *testing.T) {
+	//The camera starts 5 from the target, inside the minimum and beyond the maximum
+	for _, limits := range []struct {
+		name     string
+		min, max float32
+		amount   float32
+	}{
+		{"closer than the minimum", 8, 0, 1},
+		{"further than the maximum", 1, 3, -1},
+	} {
+		t.Run(limits.name, func(t *testing.T) {
+			camera := New(Museum)
+			camera.SetZoomLimits(limits.min, limits.max)
+			centre := mgl32.Vec2{camera.Screenwidth / 2, camera.Screenheight / 2}
+
+			//Zooming further past the limit does nothing, rather than jumping back to it
+			camera.ZoomToCursor(centre.X(), centre.Y(), limits.amount)
+			assertVec3(t, camera.Position, mgl32.Vec3{0, 0, 5})
+
+			//Zooming back towards the limit still works
+			camera.ZoomToCursor(centre.X(), centre.Y(), -limits.amount)
+			assertVec3(t, camera.Position, mgl32.Vec3{0, 0, 5 + limits.amount})
+		})
+	}
+}
+
+func TestZoomToCursorUnsupported(t *testing.T) {
+	camera := New(FPS)
+	if camera.ZoomToCursor(960, 540, 1) {
+		t.Error("expected ZoomToCursor to be refused in FPS mode")
+	}
+	camera = New(RTS)
+	original := camera.Position
+	if camera.ZoomToCursor(camera.Screenwidth/2, 0, 1) {
+		t.Error("expected ZoomToCursor above the horizon to be refused")
+	}
+	assertVec3(t, camera.Position, original)
+}